@@ -0,0 +1,63 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// adoptExistingSchema returns the adopt_existing setting of resources with a unique attribute, like the code of a
+// market. It has no default, so that existing state doesn't get a diff for it.
+func adoptExistingSchema(resourceName string, key string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Adopt an existing %s with the same %s instead of creating a new one, for example "+
+			"when the %s is already taken. The existing %s is updated to match the configuration.",
+			resourceName, key, key, resourceName),
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+}
+
+// adoptExisting looks up the resource with the same value for a unique attribute when adopt_existing is set. When
+// it exists its id is set and adopted is true, the create function then updates it to match the configuration
+// instead of creating it. A warning says that the resource was adopted.
+func adoptExisting(ctx context.Context, c *apiClient, d *schema.ResourceData, resourceType string,
+	key string) (bool, diag.Diagnostics) {
+	value, _ := d.Get(attributeKey(d, key)).(string)
+	if !d.Get("adopt_existing").(bool) || value == "" {
+		return false, nil
+	}
+
+	query := url.Values{}
+	query.Set(fmt.Sprintf("filter[q][%s_eq]", key), value)
+
+	var ids []string
+	err := listJsonApiResources(ctx, c, resourceType, query, 1, func(page []jsonApiResource) bool {
+		for _, resource := range page {
+			ids = append(ids, resource.Id)
+		}
+		return true
+	})
+	if err != nil {
+		return false, diagErr(err)
+	}
+
+	switch len(ids) {
+	case 0:
+		return false, nil
+	case 1:
+		d.SetId(ids[0])
+		return true, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Adopted an existing resource",
+			Detail: fmt.Sprintf("%s %s already has %s %q, it was adopted and updated to match the configuration "+
+				"instead of created.", resourceType, ids[0], key, value),
+		}}
+	default:
+		return false, diag.Errorf("%d %s have %s %q, adopt_existing needs a unique %s to adopt one", len(ids),
+			resourceType, key, value, key)
+	}
+}
@@ -0,0 +1,82 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAdoptServer(t *testing.T, ids ...string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/markets", r.URL.Path)
+		assert.Equal(t, "EU", r.URL.Query().Get("filter[q][code_eq]"))
+
+		var data []map[string]any
+		for _, id := range ids {
+			data = append(data, map[string]any{"id": id, "type": marketType})
+		}
+		w.Header().Set("Content-Type", jsonApiContentType)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "meta": map[string]any{"page_count": 1}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAdoptExisting(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{
+		"adopt_existing": true,
+		"code":           "EU",
+	})
+
+	adopted, diags := adoptExisting(context.Background(), testApiClient(testAdoptServer(t, "market").URL), d,
+		marketType, "code")
+	require.False(t, diags.HasError())
+	assert.True(t, adopted)
+	assert.Equal(t, "market", d.Id())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, `markets market already has code "EU"`)
+}
+
+func TestAdoptExistingNotFound(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{
+		"adopt_existing": true,
+		"code":           "EU",
+	})
+
+	adopted, diags := adoptExisting(context.Background(), testApiClient(testAdoptServer(t).URL), d,
+		marketType, "code")
+	assert.False(t, adopted)
+	assert.Empty(t, diags)
+	assert.Empty(t, d.Id())
+}
+
+func TestAdoptExistingNotUnique(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{
+		"adopt_existing": true,
+		"code":           "EU",
+	})
+
+	adopted, diags := adoptExisting(context.Background(), testApiClient(testAdoptServer(t, "a", "b").URL), d,
+		marketType, "code")
+	assert.False(t, adopted)
+	require.True(t, diags.HasError())
+	assert.Equal(t, `2 markets have code "EU", adopt_existing needs a unique code to adopt one`, diags[0].Summary)
+}
+
+func TestAdoptExistingNotSet(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{
+		"code": "EU",
+	})
+
+	adopted, diags := adoptExisting(context.Background(), testApiClient("http://localhost"), d, marketType, "code")
+	assert.False(t, adopted)
+	assert.Empty(t, diags)
+}
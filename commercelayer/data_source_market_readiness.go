@@ -0,0 +1,299 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxReadinessPages bounds the pages of payment and shipping methods read to check a market.
+const maxReadinessPages = 10
+
+type marketReadinessDataSource struct {
+	client *apiClient
+}
+
+var _ datasource.DataSourceWithConfigure = &marketReadinessDataSource{}
+
+type marketReadinessModel struct {
+	Id       types.String                  `tfsdk:"id"`
+	MarketId types.String                  `tfsdk:"market_id"`
+	Ready    types.Bool                    `tfsdk:"ready"`
+	Problems []marketReadinessProblemModel `tfsdk:"problems"`
+}
+
+type marketReadinessProblemModel struct {
+	Check   types.String `tfsdk:"check"`
+	Message types.String `tfsdk:"message"`
+}
+
+// marketReadinessProblem is a reason a market can't take orders, reported under the name of the check that found it.
+type marketReadinessProblem struct {
+	check   string
+	message string
+}
+
+func newMarketReadinessDataSource() datasource.DataSource {
+	return &marketReadinessDataSource{}
+}
+
+func (d *marketReadinessDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_market_readiness"
+}
+
+func (d *marketReadinessDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether a market can take orders: it must be enabled, its price list must have prices " +
+			"in its currency, an enabled payment method with a payment gateway and an enabled shipping method must be " +
+			"available in the market, its inventory model must have stock locations and it must have a tax " +
+			"calculator. Use it in a check block to assert on the configuration after apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The market unique identifier.",
+				Computed:    true,
+			},
+			"market_id": schema.StringAttribute{
+				Description: "The id of the market to check.",
+				Required:    true,
+			},
+			"ready": schema.BoolAttribute{
+				Description: "Whether the market can take orders, which is the case when no problems are found.",
+				Computed:    true,
+			},
+			"problems": schema.ListNestedAttribute{
+				Description: "The problems that keep the market from taking orders.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"check": schema.StringAttribute{
+							Description: "The check that found the problem, one of market, price_list, " +
+								"payment_methods, shipping_methods, inventory_model or tax_calculator.",
+							Computed: true,
+						},
+						"message": schema.StringAttribute{
+							Description: "A description of the problem.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *marketReadinessDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *marketReadinessDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	var data marketReadinessModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider",
+			"The provider must be configured before a market can be checked")
+		return
+	}
+
+	problems, err := checkMarketReadiness(ctx, d.client, data.MarketId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to check the market", err.Error())
+		return
+	}
+
+	data.Id = data.MarketId
+	data.Ready = types.BoolValue(len(problems) == 0)
+	data.Problems = []marketReadinessProblemModel{}
+	for _, problem := range problems {
+		data.Problems = append(data.Problems, marketReadinessProblemModel{
+			Check:   types.StringValue(problem.check),
+			Message: types.StringValue(problem.message),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// checkMarketReadiness runs all checks on a market and returns the problems found. Errors are only returned when the
+// checks can't be run, not for the problems they find.
+func checkMarketReadiness(ctx context.Context, c *apiClient, marketId string) ([]marketReadinessProblem, error) {
+	market, included, err := getJsonApiResource(ctx, c, marketType, marketId,
+		"price_list", "inventory_model", "tax_calculator")
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []marketReadinessProblem
+	if market.Attributes["disabled_at"] != nil {
+		problems = append(problems, marketReadinessProblem{"market", "the market is disabled"})
+	}
+
+	currencyCode := ""
+	priceListId := market.relationshipId("price_list")
+	for _, resource := range included {
+		if resource.Type == priceListType && resource.Id == priceListId {
+			currencyCode, _ = resource.Attributes["currency_code"].(string)
+		}
+	}
+
+	found, err := checkMarketPriceList(ctx, c, priceListId, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, found...)
+
+	found, err = checkMarketMethods(ctx, c, marketId, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, found...)
+
+	found, err = checkMarketInventoryModel(ctx, c, market.relationshipId("inventory_model"))
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, found...)
+
+	if market.relationshipId("tax_calculator") == "" {
+		problems = append(problems, marketReadinessProblem{"tax_calculator", "the market has no tax calculator"})
+	}
+
+	return problems, nil
+}
+
+func checkMarketPriceList(ctx context.Context, c *apiClient, priceListId string,
+	currencyCode string) ([]marketReadinessProblem, error) {
+	if priceListId == "" {
+		return []marketReadinessProblem{{"price_list", "the market has no price list"}}, nil
+	}
+
+	count, err := countJsonApiResources(ctx, c, pricesType, url.Values{
+		"filter[q][price_list_id_eq]": {priceListId},
+		"filter[q][currency_code_eq]": {currencyCode},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return []marketReadinessProblem{{"price_list",
+			fmt.Sprintf("price list %s has no prices in %s", priceListId, currencyCode)}}, nil
+	}
+
+	return nil, nil
+}
+
+// checkMarketMethods checks that an enabled payment method with a payment gateway and an enabled shipping method
+// are available in the market.
+func checkMarketMethods(ctx context.Context, c *apiClient, marketId string,
+	currencyCode string) ([]marketReadinessProblem, error) {
+	inCurrency := ""
+	if currencyCode != "" {
+		inCurrency = " in " + currencyCode
+	}
+
+	var problems []marketReadinessProblem
+	available, err := marketHasMethod(ctx, c, paymentMethodType, marketId, currencyCode, "payment_gateway")
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		problems = append(problems, marketReadinessProblem{"payment_methods",
+			"no enabled payment method with a payment gateway is available for the market" + inCurrency})
+	}
+
+	available, err = marketHasMethod(ctx, c, shippingMethodType, marketId, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		problems = append(problems, marketReadinessProblem{"shipping_methods",
+			"no enabled shipping method is available for the market" + inCurrency})
+	}
+
+	return problems, nil
+}
+
+// marketHasMethod reports whether an enabled payment or shipping method is available in a market. Methods without a
+// market are available in all markets, and methods without a currency in all currencies. The required relationships
+// must be set as well.
+func marketHasMethod(ctx context.Context, c *apiClient, resourceType string, marketId string, currencyCode string,
+	requiredRelationships ...string) (bool, error) {
+	query := url.Values{}
+	query.Set("filter[q][disabled_at_null]", "true")
+	query.Set("include", strings.Join(append([]string{"market"}, requiredRelationships...), ","))
+
+	found := false
+	err := listJsonApiResources(ctx, c, resourceType, query, maxReadinessPages, func(page []jsonApiResource) bool {
+		for _, method := range page {
+			if methodAvailable(method, marketId, currencyCode, requiredRelationships) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+
+	return found, err
+}
+
+func methodAvailable(method jsonApiResource, marketId string, currencyCode string,
+	requiredRelationships []string) bool {
+	if method.Attributes["disabled_at"] != nil {
+		return false
+	}
+	if methodMarketId := method.relationshipId("market"); methodMarketId != "" && methodMarketId != marketId {
+		return false
+	}
+	if methodCurrencyCode, _ := method.Attributes["currency_code"].(string); methodCurrencyCode != "" &&
+		currencyCode != "" && methodCurrencyCode != currencyCode {
+		return false
+	}
+	for _, relationship := range requiredRelationships {
+		if method.relationshipId(relationship) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func checkMarketInventoryModel(ctx context.Context, c *apiClient,
+	inventoryModelId string) ([]marketReadinessProblem, error) {
+	if inventoryModelId == "" {
+		return []marketReadinessProblem{{"inventory_model", "the market has no inventory model"}}, nil
+	}
+
+	count, err := countJsonApiResources(ctx, c, inventoryStockLocationsType, url.Values{
+		"filter[q][inventory_model_id_eq]": {inventoryModelId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return []marketReadinessProblem{{"inventory_model",
+			fmt.Sprintf("inventory model %s has no stock locations", inventoryModelId)}}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,211 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReadinessServer serves the resources a market readiness check reads. The lists are served as a single page.
+func testReadinessServer(t *testing.T, resources map[string]any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if count, ok := document.(int); ok {
+			assert.Equal(t, "1", r.URL.Query().Get("page[size]"))
+			document = map[string]any{"data": []any{}, "meta": map[string]any{"record_count": count}}
+		}
+
+		w.Header().Set("Content-Type", jsonApiContentType)
+		_ = json.NewEncoder(w).Encode(document)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testLinkage(resourceType string, id string) map[string]any {
+	if id == "" {
+		return map[string]any{"data": nil}
+	}
+	return map[string]any{"data": map[string]any{"type": resourceType, "id": id}}
+}
+
+func testReadinessMethods(methods ...map[string]any) map[string]any {
+	return map[string]any{"data": methods, "meta": map[string]any{"page_count": 1}}
+}
+
+func testReadinessResources() map[string]any {
+	return map[string]any{
+		"/markets/market": map[string]any{
+			"data": map[string]any{
+				"id":         "market",
+				"type":       marketType,
+				"attributes": map[string]any{"disabled_at": nil},
+				"relationships": map[string]any{
+					"price_list":      testLinkage(priceListType, "price-list"),
+					"inventory_model": testLinkage(inventoryModelType, "inventory-model"),
+					"tax_calculator":  testLinkage(manualTaxCalculatorsType, "tax-calculator"),
+				},
+			},
+			"included": []any{
+				map[string]any{
+					"id":         "price-list",
+					"type":       priceListType,
+					"attributes": map[string]any{"currency_code": "EUR"},
+				},
+			},
+		},
+		"/prices":                    12,
+		"/inventory_stock_locations": 1,
+		"/payment_methods": testReadinessMethods(
+			map[string]any{
+				"id":         "other-market",
+				"attributes": map[string]any{"currency_code": "EUR"},
+				"relationships": map[string]any{
+					"market":          testLinkage(marketType, "other"),
+					"payment_gateway": testLinkage(paymentGatewayType, "gateway"),
+				},
+			},
+			map[string]any{
+				"id":         "all-markets",
+				"attributes": map[string]any{"currency_code": "EUR"},
+				"relationships": map[string]any{
+					"market":          testLinkage(marketType, ""),
+					"payment_gateway": testLinkage(paymentGatewayType, "gateway"),
+				},
+			},
+		),
+		"/shipping_methods": testReadinessMethods(
+			map[string]any{
+				"id":            "market",
+				"attributes":    map[string]any{"currency_code": nil},
+				"relationships": map[string]any{"market": testLinkage(marketType, "market")},
+			},
+		),
+	}
+}
+
+func TestCheckMarketReadinessReady(t *testing.T) {
+	server := testReadinessServer(t, testReadinessResources())
+
+	problems, err := checkMarketReadiness(context.Background(), testApiClient(server.URL), "market")
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCheckMarketReadinessProblems(t *testing.T) {
+	resources := testReadinessResources()
+	market := resources["/markets/market"].(map[string]any)["data"].(map[string]any)
+	market["attributes"] = map[string]any{"disabled_at": "2024-10-24T16:07:17.169Z"}
+	market["relationships"].(map[string]any)["tax_calculator"] = testLinkage(taxCalculatorType, "")
+	resources["/prices"] = 0
+	resources["/inventory_stock_locations"] = 0
+	resources["/payment_methods"] = testReadinessMethods(
+		map[string]any{
+			"id":         "usd",
+			"attributes": map[string]any{"currency_code": "USD"},
+			"relationships": map[string]any{
+				"market":          testLinkage(marketType, ""),
+				"payment_gateway": testLinkage(paymentGatewayType, "gateway"),
+			},
+		},
+		map[string]any{
+			"id":         "no-gateway",
+			"attributes": map[string]any{"currency_code": "EUR"},
+			"relationships": map[string]any{
+				"market":          testLinkage(marketType, "market"),
+				"payment_gateway": testLinkage(paymentGatewayType, ""),
+			},
+		},
+	)
+	resources["/shipping_methods"] = testReadinessMethods()
+	server := testReadinessServer(t, resources)
+
+	problems, err := checkMarketReadiness(context.Background(), testApiClient(server.URL), "market")
+	require.NoError(t, err)
+	assert.Equal(t, []marketReadinessProblem{
+		{"market", "the market is disabled"},
+		{"price_list", "price list price-list has no prices in EUR"},
+		{"payment_methods", "no enabled payment method with a payment gateway is available for the market in EUR"},
+		{"shipping_methods", "no enabled shipping method is available for the market in EUR"},
+		{"inventory_model", "inventory model inventory-model has no stock locations"},
+		{"tax_calculator", "the market has no tax calculator"},
+	}, problems)
+}
+
+func TestCheckMarketReadinessMissingRelationships(t *testing.T) {
+	resources := testReadinessResources()
+	resources["/markets/market"] = map[string]any{
+		"data": map[string]any{"id": "market", "type": marketType, "attributes": map[string]any{}},
+	}
+	server := testReadinessServer(t, resources)
+
+	problems, err := checkMarketReadiness(context.Background(), testApiClient(server.URL), "market")
+	require.NoError(t, err)
+	assert.Equal(t, []marketReadinessProblem{
+		{"price_list", "the market has no price list"},
+		{"inventory_model", "the market has no inventory model"},
+		{"tax_calculator", "the market has no tax calculator"},
+	}, problems)
+}
+
+func TestCheckMarketReadinessNotFound(t *testing.T) {
+	server := testReadinessServer(t, testReadinessResources())
+
+	_, err := checkMarketReadiness(context.Background(), testApiClient(server.URL), "unknown")
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func (s *AcceptanceSuite) TestAccMarketReadiness_basic() {
+	dataSourceName := "data.commercelayer_market_readiness.incentro_market"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckMarketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join([]string{
+					testAccAddressCreate(dataSourceName),
+					testAccInventoryModelCreate(dataSourceName),
+					testAccMerchantCreate(dataSourceName),
+					testAccPriceListCreate(dataSourceName),
+					testAccMarketCreate(dataSourceName),
+					testAccMarketReadiness()}, "\n",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id",
+						"commercelayer_market.incentro_market", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "ready", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "problems.*",
+						map[string]string{"check": "price_list"}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "problems.*",
+						map[string]string{"check": "inventory_model"}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "problems.*",
+						map[string]string{"check": "tax_calculator"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccMarketReadiness() string {
+	return `
+		data "commercelayer_market_readiness" "incentro_market" {
+			market_id = commercelayer_market.incentro_market.id
+		}
+	`
+}
@@ -0,0 +1,290 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	eventCallbackType = "event_callbacks"
+
+	eventCallbackStatusSuccess = "success"
+	eventCallbackStatusFailure = "failure"
+
+	defaultEventCallbacksLimit = 25
+	maxEventCallbacksLimit     = 100
+
+	// maxEventCallbackPages bounds the pages read while looking for callbacks with the requested status, as a
+	// healthy webhook can have many successful callbacks in front of the failures.
+	maxEventCallbackPages = 10
+)
+
+type webhookEventCallbacksDataSource struct {
+	client *apiClient
+}
+
+var _ datasource.DataSourceWithConfigure = &webhookEventCallbacksDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &webhookEventCallbacksDataSource{}
+
+type webhookEventCallbacksModel struct {
+	Id             types.String                `tfsdk:"id"`
+	WebhookId      types.String                `tfsdk:"webhook_id"`
+	Status         types.String                `tfsdk:"status"`
+	CreatedAfter   types.String                `tfsdk:"created_after"`
+	CreatedBefore  types.String                `tfsdk:"created_before"`
+	Limit          types.Int64                 `tfsdk:"limit"`
+	EventCallbacks []webhookEventCallbackModel `tfsdk:"event_callbacks"`
+}
+
+type webhookEventCallbackModel struct {
+	Id              types.String `tfsdk:"id"`
+	CallbackUrl     types.String `tfsdk:"callback_url"`
+	ResponseCode    types.Int64  `tfsdk:"response_code"`
+	ResponseMessage types.String `tfsdk:"response_message"`
+	Success         types.Bool   `tfsdk:"success"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	UpdatedAt       types.String `tfsdk:"updated_at"`
+}
+
+func newWebhookEventCallbacksDataSource() datasource.DataSource {
+	return &webhookEventCallbacksDataSource{}
+}
+
+func (d *webhookEventCallbacksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_event_callbacks"
+}
+
+func (d *webhookEventCallbacksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The most recent event callbacks of a webhook, which record the response of every call to its " +
+			"callback URL. Use it to assert on the delivery health of a webhook, for example in a check block.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The webhook unique identifier.",
+				Computed:    true,
+			},
+			"webhook_id": schema.StringAttribute{
+				Description: "The id of the webhook to list the event callbacks of.",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: fmt.Sprintf("Only list the callbacks with this status: %s for a 2xx response code, "+
+					"%s otherwise. The status is checked on the %d most recent callbacks in the time window.",
+					eventCallbackStatusSuccess, eventCallbackStatusFailure, maxEventCallbackPages*jsonApiPageSize),
+				Optional: true,
+			},
+			"created_after": schema.StringAttribute{
+				Description: "Only list the callbacks created at or after this time, in RFC 3339 format. Combine with " +
+					"timeadd(plantimestamp(), \"-24h\") for a sliding window.",
+				Optional: true,
+			},
+			"created_before": schema.StringAttribute{
+				Description: "Only list the callbacks created at or before this time, in RFC 3339 format.",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of callbacks to list, at most %d. Defaults to %d.",
+					maxEventCallbacksLimit, defaultEventCallbacksLimit),
+				Optional: true,
+			},
+			"event_callbacks": schema.ListNestedAttribute{
+				Description: "The event callbacks, most recent first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The event callback unique identifier.",
+							Computed:    true,
+						},
+						"callback_url": schema.StringAttribute{
+							Description: "The URI of the callback, inherited by the associated webhook.",
+							Computed:    true,
+						},
+						"response_code": schema.Int64Attribute{
+							Description: "The HTTP response code of the callback, null when no response was received.",
+							Computed:    true,
+						},
+						"response_message": schema.StringAttribute{
+							Description: "The HTTP response message of the callback.",
+							Computed:    true,
+						},
+						"success": schema.BoolAttribute{
+							Description: "Whether the callback received a 2xx response code.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "The time the callback was made, in ISO 8601 format.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "The time the callback was last updated, in ISO 8601 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *webhookEventCallbacksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = client
+}
+
+func (d *webhookEventCallbacksDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest,
+	resp *datasource.ValidateConfigResponse) {
+	var data webhookEventCallbacksModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status := data.Status.ValueString()
+	if status != "" && status != eventCallbackStatusSuccess && status != eventCallbackStatusFailure {
+		resp.Diagnostics.AddAttributeError(path.Root("status"), "Invalid status",
+			fmt.Sprintf("Status must be %s or %s, got %s", eventCallbackStatusSuccess, eventCallbackStatusFailure,
+				status))
+	}
+
+	for key, value := range map[string]types.String{
+		"created_after":  data.CreatedAfter,
+		"created_before": data.CreatedBefore,
+	} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(key), "Invalid time",
+				fmt.Sprintf("%s must be in RFC 3339 format: %s", key, err))
+		}
+	}
+
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() {
+		limit := data.Limit.ValueInt64()
+		if limit < 1 || limit > maxEventCallbacksLimit {
+			resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid limit",
+				fmt.Sprintf("Limit must be between 1 and %d, got %d", maxEventCallbacksLimit, limit))
+		}
+	}
+}
+
+func (d *webhookEventCallbacksDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	var data webhookEventCallbacksModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider",
+			"The provider must be configured before event callbacks can be read")
+		return
+	}
+
+	limit := int64(defaultEventCallbacksLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	callbacks, err := listWebhookEventCallbacks(ctx, d.client, data.WebhookId.ValueString(),
+		data.CreatedAfter.ValueString(), data.CreatedBefore.ValueString(), data.Status.ValueString(), int(limit))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read the event callbacks", err.Error())
+		return
+	}
+
+	data.Id = data.WebhookId
+	data.EventCallbacks = callbacks
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// listWebhookEventCallbacks lists the most recent event callbacks of a webhook. The time window is filtered by
+// Commerce Layer, the status is filtered here as it depends on the response code range.
+func listWebhookEventCallbacks(ctx context.Context, c *apiClient, webhookId string, createdAfter string,
+	createdBefore string, status string, limit int) ([]webhookEventCallbackModel, error) {
+	query := url.Values{}
+	query.Set("filter[q][webhook_id_eq]", webhookId)
+	query.Set("sort", "-created_at")
+	if createdAfter != "" {
+		query.Set("filter[q][created_at_gteq]", createdAfter)
+	}
+	if createdBefore != "" {
+		query.Set("filter[q][created_at_lteq]", createdBefore)
+	}
+
+	maxPages := maxEventCallbackPages
+	if status == "" {
+		maxPages = (limit + jsonApiPageSize - 1) / jsonApiPageSize
+	}
+
+	callbacks := []webhookEventCallbackModel{}
+	err := listJsonApiResources(ctx, c, eventCallbackType, query, maxPages, func(page []jsonApiResource) bool {
+		for _, resource := range page {
+			callback := eventCallbackFromResource(resource)
+			if status != "" && callback.Success.ValueBool() != (status == eventCallbackStatusSuccess) {
+				continue
+			}
+			callbacks = append(callbacks, callback)
+			if len(callbacks) == limit {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return callbacks, nil
+}
+
+// eventCallbackFromResource reads an event callback from a list response. Commerce Layer returns the response code
+// as a string, it is read from a number as well to be safe.
+func eventCallbackFromResource(resource jsonApiResource) webhookEventCallbackModel {
+	responseCode := types.Int64Null()
+	if code, err := strconv.ParseInt(fmt.Sprint(resource.Attributes["response_code"]), 10, 64); err == nil {
+		responseCode = types.Int64Value(code)
+	}
+
+	return webhookEventCallbackModel{
+		Id:              types.StringValue(resource.Id),
+		CallbackUrl:     stringAttributeValue(resource.Attributes["callback_url"]),
+		ResponseCode:    responseCode,
+		ResponseMessage: stringAttributeValue(resource.Attributes["response_message"]),
+		Success:         types.BoolValue(responseCode.ValueInt64() >= 200 && responseCode.ValueInt64() < 300),
+		CreatedAt:       stringAttributeValue(resource.Attributes["created_at"]),
+		UpdatedAt:       stringAttributeValue(resource.Attributes["updated_at"]),
+	}
+}
+
+// stringAttributeValue converts a decoded JSON attribute to a string value, null when it is missing.
+func stringAttributeValue(value any) types.String {
+	s, ok := value.(string)
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,179 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEventCallbacksServer serves event callbacks in pages, with a 500 response code for every third callback.
+func testEventCallbacksServer(t *testing.T, total int, requests *[]*http.Request) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/event_callbacks", r.URL.Path)
+		*requests = append(*requests, r)
+
+		size, _ := strconv.Atoi(r.URL.Query().Get("page[size]"))
+		number, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+
+		data := []map[string]any{}
+		for i := (number - 1) * size; i < min(number*size, total); i++ {
+			responseCode := "200"
+			if i%3 == 2 {
+				responseCode = "500"
+			}
+			data = append(data, map[string]any{
+				"id":   fmt.Sprintf("callback-%d", i),
+				"type": eventCallbackType,
+				"attributes": map[string]any{
+					"callback_url":     "https://example.com/webhook",
+					"response_code":    responseCode,
+					"response_message": http.StatusText(map[string]int{"200": 200, "500": 500}[responseCode]),
+					"created_at":       "2024-10-24T16:07:17.169Z",
+				},
+			})
+		}
+
+		w.Header().Set("Content-Type", jsonApiContentType)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": data,
+			"meta": map[string]any{"record_count": total, "page_count": (total + size - 1) / size},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListWebhookEventCallbacks(t *testing.T) {
+	var requests []*http.Request
+	server := testEventCallbacksServer(t, 30, &requests)
+
+	callbacks, err := listWebhookEventCallbacks(context.Background(), testApiClient(server.URL), "xYZkjABcde",
+		"2024-10-24T00:00:00Z", "", "", 5)
+	require.NoError(t, err)
+
+	require.Len(t, callbacks, 5)
+	assert.Equal(t, "callback-0", callbacks[0].Id.ValueString())
+	assert.Equal(t, int64(500), callbacks[2].ResponseCode.ValueInt64())
+	assert.False(t, callbacks[2].Success.ValueBool())
+	assert.Equal(t, "Internal Server Error", callbacks[2].ResponseMessage.ValueString())
+	assert.True(t, callbacks[2].UpdatedAt.IsNull())
+
+	require.Len(t, requests, 1)
+	query := requests[0].URL.Query()
+	assert.Equal(t, "xYZkjABcde", query.Get("filter[q][webhook_id_eq]"))
+	assert.Equal(t, "2024-10-24T00:00:00Z", query.Get("filter[q][created_at_gteq]"))
+	assert.False(t, query.Has("filter[q][created_at_lteq]"))
+	assert.Equal(t, "-created_at", query.Get("sort"))
+}
+
+func TestListWebhookEventCallbacksStatus(t *testing.T) {
+	var requests []*http.Request
+	server := testEventCallbacksServer(t, 60, &requests)
+
+	callbacks, err := listWebhookEventCallbacks(context.Background(), testApiClient(server.URL), "xYZkjABcde",
+		"", "", eventCallbackStatusFailure, 10)
+	require.NoError(t, err)
+
+	require.Len(t, callbacks, 10)
+	for _, callback := range callbacks {
+		assert.Equal(t, int64(500), callback.ResponseCode.ValueInt64())
+	}
+	assert.Len(t, requests, 2)
+}
+
+func TestListWebhookEventCallbacksLastPage(t *testing.T) {
+	var requests []*http.Request
+	server := testEventCallbacksServer(t, 30, &requests)
+
+	callbacks, err := listWebhookEventCallbacks(context.Background(), testApiClient(server.URL), "xYZkjABcde",
+		"", "", eventCallbackStatusSuccess, 100)
+	require.NoError(t, err)
+
+	assert.Len(t, callbacks, 20)
+	assert.Len(t, requests, 2)
+}
+
+func TestListWebhookEventCallbacksError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := listWebhookEventCallbacks(context.Background(), testApiClient(server.URL), "xYZkjABcde", "", "", "", 5)
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestEventCallbackFromResource(t *testing.T) {
+	callback := eventCallbackFromResource(jsonApiResource{
+		Id:         "xYZkjABcde",
+		Attributes: map[string]any{"response_code": json.Number("204")},
+	})
+	assert.Equal(t, int64(204), callback.ResponseCode.ValueInt64())
+	assert.True(t, callback.Success.ValueBool())
+	assert.True(t, callback.CallbackUrl.IsNull())
+
+	callback = eventCallbackFromResource(jsonApiResource{Id: "xYZkjABcde", Attributes: map[string]any{}})
+	assert.Equal(t, types.Int64Null(), callback.ResponseCode)
+	assert.False(t, callback.Success.ValueBool())
+}
+
+func (s *AcceptanceSuite) TestAccWebhookEventCallbacks_basic() {
+	dataSourceName := "data.commercelayer_webhook_event_callbacks.incentro_webhook"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWebhookEventCallbacks(dataSourceName, "failed", "2024-10-24T00:00:00Z"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Status must be success or failure"),
+			},
+			{
+				Config:      testAccWebhookEventCallbacks(dataSourceName, "failure", "yesterday"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("created_after must be in RFC 3339 format"),
+			},
+			{
+				Config: testAccWebhookEventCallbacks(dataSourceName, "failure", "2024-10-24T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id",
+						"commercelayer_webhook.incentro_webhook", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "event_callbacks.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWebhookEventCallbacks(testName string, status string, createdAfter string) string {
+	return hclTemplate(`
+		resource "commercelayer_webhook" "incentro_webhook" {
+			name         = "incentro webhook"
+			topic        = "orders.create"
+			callback_url = "http://example.url"
+			metadata = {
+		 	  testName: "{{.testName}}"
+			}
+		}
+
+		data "commercelayer_webhook_event_callbacks" "incentro_webhook" {
+			webhook_id    = commercelayer_webhook.incentro_webhook.id
+			status        = "{{.status}}"
+			created_after = "{{.createdAfter}}"
+		}
+	`, map[string]any{"testName": testName, "status": status, "createdAfter": createdAfter})
+}
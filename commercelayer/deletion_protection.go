@@ -0,0 +1,69 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// protectedResource adds deletion protection to a resource that carries live orders, like a market. Deletes fail
+// while it is enabled, so it has to be turned off in an apply before the resource can be destroyed or replaced.
+func protectedResource(r *schema.Resource) *schema.Resource {
+	r.Schema["deletion_protection"] = &schema.Schema{
+		Description: "Whether Terraform is prevented from deleting the resource, for example on a destroy or when " +
+			"it is replaced. Defaults to the deletion_protection setting of the provider. Set it to false and apply " +
+			"before destroying the resource.",
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	}
+
+	deleteContext := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+		if deletionProtected(d, i.(*apiClient)) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Deletion protection is enabled",
+				Detail: fmt.Sprintf("%s %s can't be deleted while deletion_protection is enabled. Set "+
+					"deletion_protection = false and apply before destroying it.", d.Get("type"), d.Id()),
+			}}
+		}
+		return deleteContext(ctx, d, i)
+	}
+
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.All(r.CustomizeDiff, deletionProtectionDefault)
+	} else {
+		r.CustomizeDiff = deletionProtectionDefault
+	}
+
+	return r
+}
+
+// deletionProtectionDefault plans the deletion_protection setting of the provider for resources that don't set it,
+// so that the state records whether a resource is protected.
+func deletionProtectionDefault(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	c, ok := i.(*apiClient)
+	config := d.GetRawConfig()
+	if !ok || config.IsNull() || !config.IsKnown() || !config.GetAttr("deletion_protection").IsNull() {
+		return nil
+	}
+
+	if d.Id() == "" || d.Get("deletion_protection").(bool) != c.deletionProtection {
+		return d.SetNew("deletion_protection", c.deletionProtection)
+	}
+	return nil
+}
+
+// deletionProtected reports whether a resource is protected. Resources created before deletion protection was added
+// have no value in their state, they are protected when the provider protects resources by default.
+func deletionProtected(d *schema.ResourceData, c *apiClient) bool {
+	state := d.GetRawState()
+	if state.IsNull() || !state.IsKnown() || state.GetAttr("deletion_protection").IsNull() {
+		return c.deletionProtection
+	}
+	return d.Get("deletion_protection").(bool)
+}
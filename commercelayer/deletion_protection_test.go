@@ -0,0 +1,74 @@
+package commercelayer
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProtectedResourceData(r *schema.Resource, deletionProtection cty.Value) *schema.ResourceData {
+	attributes := map[string]string{"type": marketType}
+	if !deletionProtection.IsNull() {
+		attributes["deletion_protection"] = strconv.FormatBool(deletionProtection.True())
+	}
+
+	return r.Data(&terraform.InstanceState{
+		ID:         "market",
+		Attributes: attributes,
+		RawState: cty.ObjectVal(map[string]cty.Value{
+			"type":                cty.StringVal(marketType),
+			"deletion_protection": deletionProtection,
+		}),
+	})
+}
+
+func TestProtectedResourceDelete(t *testing.T) {
+	deleted := false
+	r := protectedResource(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {Type: schema.TypeString, Computed: true},
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			deleted = true
+			return nil
+		},
+	})
+	c := testApiClient("")
+
+	diags := r.DeleteContext(context.Background(), testProtectedResourceData(r, cty.True), c)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Deletion protection is enabled", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "markets market can't be deleted")
+	assert.False(t, deleted)
+
+	diags = r.DeleteContext(context.Background(), testProtectedResourceData(r, cty.False), c)
+	assert.False(t, diags.HasError())
+	assert.True(t, deleted)
+}
+
+func TestDeletionProtected(t *testing.T) {
+	r := protectedResource(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {Type: schema.TypeString, Computed: true},
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+			return nil
+		},
+	})
+	c := testApiClient("")
+
+	assert.True(t, deletionProtected(testProtectedResourceData(r, cty.True), c))
+	assert.False(t, deletionProtected(testProtectedResourceData(r, cty.False), c))
+	assert.False(t, deletionProtected(testProtectedResourceData(r, cty.NullVal(cty.Bool)), c))
+
+	c.deletionProtection = true
+	assert.False(t, deletionProtected(testProtectedResourceData(r, cty.False), c))
+	assert.True(t, deletionProtected(testProtectedResourceData(r, cty.NullVal(cty.Bool)), c))
+}
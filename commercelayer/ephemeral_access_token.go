@@ -0,0 +1,139 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+type accessTokenEphemeralResource struct {
+	client *apiClient
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+
+type accessTokenModel struct {
+	ClientId    types.String `tfsdk:"client_id"`
+	Scope       types.String `tfsdk:"scope"`
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+func newAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+func (r *accessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *accessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An access token for the Commerce Layer API, requested with the credentials of the provider or " +
+			"with the client id of a sales channel. The token is never stored in the state, which makes it " +
+			"suitable to hand to other providers or provisioners. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				Description: "The client id of a sales channel to request the token for, instead of the credentials " +
+					"of the provider. Sales channel tokens are requested without a client secret.",
+				Optional: true,
+			},
+			"scope": schema.StringAttribute{
+				Description: "The scope of the token, for example market:id:xYZkjABcde. When left empty, the " +
+					"default scope of the client applies. Set to the scope Commerce Layer granted.",
+				Optional: true,
+				Computed: true,
+			},
+			"access_token": schema.StringAttribute{
+				Description: "The access token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token_type": schema.StringAttribute{
+				Description: "The type of the access token, usually bearer.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "The time the access token expires, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *accessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T", req.ProviderData))
+		return
+	}
+
+	r.client = client
+}
+
+func (r *accessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse) {
+	var data accessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider",
+			"The provider must be configured before an access token can be requested")
+		return
+	}
+
+	token, err := requestAccessToken(r.client.tokenContext(ctx), r.client.credentials, data.ClientId.ValueString(),
+		data.Scope.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to request an access token", err.Error())
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.TokenType = types.StringValue(token.TokenType)
+	data.ExpiresAt = types.StringNull()
+	if !token.Expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+	if scope, ok := token.Extra("scope").(string); ok && scope != "" {
+		data.Scope = types.StringValue(scope)
+	} else if data.Scope.IsUnknown() {
+		data.Scope = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
+
+// requestAccessToken requests a new access token with the client credentials of the provider. A sales channel client
+// id replaces those credentials, as sales channels authenticate without a client secret.
+func requestAccessToken(ctx context.Context, credentials clientcredentials.Config, clientId string,
+	scope string) (*oauth2.Token, error) {
+	if clientId != "" {
+		credentials.ClientID = clientId
+		credentials.ClientSecret = ""
+		credentials.AuthStyle = oauth2.AuthStyleInParams
+	}
+	if scope != "" {
+		credentials.Scopes = []string{scope}
+	}
+
+	return credentials.Token(ctx)
+}
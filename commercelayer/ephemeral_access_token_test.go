@@ -0,0 +1,70 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+func testTokenServer(t *testing.T, form *url.Values) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		*form = r.PostForm
+		if username, password, ok := r.BasicAuth(); ok {
+			form.Set("client_id", username)
+			form.Set("client_secret", password)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "token",
+			"token_type":   "bearer",
+			"expires_in":   7200,
+			"scope":        r.PostForm.Get("scope"),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRequestAccessToken(t *testing.T) {
+	var form url.Values
+	server := testTokenServer(t, &form)
+
+	token, err := requestAccessToken(context.Background(), clientcredentials.Config{
+		ClientID:     "integration",
+		ClientSecret: "secret",
+		TokenURL:     server.URL,
+	}, "", "market:id:xYZkjABcde")
+	require.NoError(t, err)
+
+	assert.Equal(t, "token", token.AccessToken)
+	assert.Equal(t, "market:id:xYZkjABcde", token.Extra("scope"))
+	assert.False(t, token.Expiry.IsZero())
+	assert.Equal(t, "integration", form.Get("client_id"))
+	assert.Equal(t, "secret", form.Get("client_secret"))
+	assert.Equal(t, "client_credentials", form.Get("grant_type"))
+}
+
+func TestRequestAccessTokenSalesChannel(t *testing.T) {
+	var form url.Values
+	server := testTokenServer(t, &form)
+
+	_, err := requestAccessToken(context.Background(), clientcredentials.Config{
+		ClientID:     "integration",
+		ClientSecret: "secret",
+		TokenURL:     server.URL,
+	}, "sales-channel", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "sales-channel", form.Get("client_id"))
+	assert.False(t, form.Has("client_secret"))
+	assert.False(t, form.Has("scope"))
+}
@@ -0,0 +1,540 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// exportMaxPages bounds the pages read per resource type, which allows exporting 10000 resources of every type. An
+// export of a type with more resources fails rather than writing part of them.
+const exportMaxPages = 400
+
+// exportResourceType is a resource type an organization is exported with, and the API type it is listed from.
+type exportResourceType struct {
+	tfType  string
+	apiType string
+}
+
+// exportResourceTypes are the resource types an organization is exported with.
+var exportResourceTypes = []exportResourceType{
+	{"commercelayer_address", addressType},
+	{"commercelayer_merchant", merchantType},
+	{"commercelayer_customer_group", customerGroupType},
+	{"commercelayer_price_list", priceListType},
+	{"commercelayer_google_geocoder", googleGeocodersType},
+	{"commercelayer_bing_geocoder", bingGeocodersType},
+	{"commercelayer_external_tax_calculator", externalTaxCalculatorType},
+	{"commercelayer_manual_tax_calculator", manualTaxCalculatorsType},
+	{"commercelayer_taxjar_accounts", taxjarAccountsType},
+	{"commercelayer_inventory_model", inventoryModelType},
+	{"commercelayer_subscription_model", subscriptionModelsType},
+	{"commercelayer_stock_location", stockLocationType},
+	{"commercelayer_inventory_stock_location", inventoryStockLocationsType},
+	{"commercelayer_inventory_return_location", inventoryReturnLocationsType},
+	{"commercelayer_market", marketType},
+	{"commercelayer_price_list_scheduler", priceListSchedulersType},
+	{"commercelayer_shipping_category", shippingCategoryType},
+	{"commercelayer_shipping_zone", shippingZoneType},
+	{"commercelayer_shipping_method", shippingMethodType},
+	{"commercelayer_delivery_lead_time", deliveryLeadTimesType},
+	{"commercelayer_manual_gateway", manualGatewaysType},
+	{"commercelayer_adyen_gateway", adyenGatewaysType},
+	{"commercelayer_axerve_gateway", axerveGatewaysType},
+	{"commercelayer_braintree_gateway", braintreeGatewaysType},
+	{"commercelayer_checkout_com_gateway", checkoutComGatewaysType},
+	{"commercelayer_external_gateway", externalGatewayType},
+	{"commercelayer_klarna_gateway", klarnaGatewaysType},
+	{"commercelayer_paypal_gateway", paypalGatewaysType},
+	{"commercelayer_satispay_gateway", satispayGatewaysType},
+	{"commercelayer_stripe_gateway", stripeGatewaysType},
+	{"commercelayer_payment_method", paymentMethodType},
+	{"commercelayer_customer", customersType},
+	{"commercelayer_customer_address", customerAddressesType},
+	{"commercelayer_webhook", webhookType},
+}
+
+var exportNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ExportConfig configures the export of an organization.
+type ExportConfig struct {
+	// Dir is the directory the configuration is written to. Existing files are never overwritten.
+	Dir string
+	// ResourceTypes limits the export to these resource types, all supported types are exported when empty.
+	ResourceTypes []string
+}
+
+// exportedResource is a resource of the organization, with the name it is exported under.
+type exportedResource struct {
+	tfType   string
+	name     string
+	resource jsonApiResource
+}
+
+// Export writes the resources of an organization as Terraform configuration, with import blocks to adopt them and
+// variables for their secrets. The credentials are read from the environment variables the provider reads.
+func Export(ctx context.Context, config ExportConfig, opts ...ProviderOption) error {
+	c, err := environmentApiClient(opts...)
+	if err != nil {
+		return err
+	}
+
+	files, err := exportOrganization(ctx, c, config.ResourceTypes)
+	if err != nil {
+		return err
+	}
+
+	return writeExportFiles(config.Dir, files)
+}
+
+// environmentApiClient creates an API client with the provider settings of the environment, for the commands of the
+// provider binary.
+func environmentApiClient(opts ...ProviderOption) (*apiClient, error) {
+	var errs []error
+	setting := func(key string) string {
+		value, err := baseSchema[key].DefaultValue()
+		errs = append(errs, err)
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+	settings := providerSettings{
+		clientId:        setting("client_id"),
+		clientSecret:    setting("client_secret"),
+		apiEndpoint:     setting("api_endpoint"),
+		authEndpoint:    setting("auth_endpoint"),
+		rateLimiter:     true,
+		pollInterval:    setting("poll_interval"),
+		maxPollInterval: setting("max_poll_interval"),
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if settings.clientId == "" || settings.apiEndpoint == "" {
+		return nil, errors.New("COMMERCELAYER_CLIENT_ID and COMMERCELAYER_API_ENDPOINT must be set")
+	}
+
+	return newConfiguration(opts...).newApiClient(settings)
+}
+
+// getExportResourceType returns the export settings of a resource type.
+func getExportResourceType(tfType string) (exportResourceType, error) {
+	index := slices.IndexFunc(exportResourceTypes, func(t exportResourceType) bool {
+		return t.tfType == tfType
+	})
+	if index < 0 {
+		return exportResourceType{}, fmt.Errorf("%s can't be exported", tfType)
+	}
+	return exportResourceTypes[index], nil
+}
+
+// exportOrganization lists the resources of the organization and renders them to files by name.
+func exportOrganization(ctx context.Context, c *apiClient, resourceTypes []string) (map[string][]byte, error) {
+	for _, resourceType := range resourceTypes {
+		if _, err := getExportResourceType(resourceType); err != nil {
+			return nil, err
+		}
+	}
+
+	var resources []exportedResource
+	for _, resourceType := range exportResourceTypes {
+		if len(resourceTypes) > 0 && !slices.Contains(resourceTypes, resourceType.tfType) {
+			continue
+		}
+
+		listed, err := listExportedResources(ctx, c, resourceType, url.Values{})
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, listed...)
+	}
+
+	return renderExport(resources)
+}
+
+// listExportedResources lists and names the resources of a type that match the query.
+func listExportedResources(ctx context.Context, c *apiClient, resourceType exportResourceType,
+	query url.Values) ([]exportedResource, error) {
+	if relationships := exportRelationships(baseResourceMap[resourceType.tfType]); len(relationships) > 0 {
+		query.Set("include", strings.Join(relationships, ","))
+	}
+
+	var listed []jsonApiResource
+	err := listJsonApiResources(ctx, c, resourceType.apiType, query, exportMaxPages,
+		func(page []jsonApiResource) bool {
+			listed = append(listed, page...)
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", resourceType.tfType, err)
+	}
+
+	// A full last page means more resources may match than are read, which would write an incomplete configuration.
+	if len(listed) == exportMaxPages*jsonApiPageSize {
+		count, err := countJsonApiResources(ctx, c, resourceType.apiType, query)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", resourceType.tfType, err)
+		}
+		if count > len(listed) {
+			return nil, fmt.Errorf("%s: %d resources match, more than the %d that are read for a resource type",
+				resourceType.tfType, count, len(listed))
+		}
+	}
+
+	return nameExportedResources(resourceType.tfType, listed), nil
+}
+
+// exportRelationships returns the relationships of a resource, which are included in the list requests so that
+// their linkage is returned.
+func exportRelationships(r *schema.Resource) []string {
+	var relationships []string
+	for _, key := range sortedKeys(blockSchema(r, "relationships")) {
+		if name, ok := strings.CutSuffix(key, "_ids"); ok {
+			relationships = append(relationships, name+"s")
+		} else if name, ok := strings.CutSuffix(key, "_id"); ok {
+			relationships = append(relationships, name)
+		}
+	}
+	return relationships
+}
+
+// nameExportedResources names the resources of a type after their name, code, email or reference, falling back to
+// their id. Names are made unique by appending a number.
+func nameExportedResources(tfType string, listed []jsonApiResource) []exportedResource {
+	used := map[string]bool{}
+	resources := make([]exportedResource, 0, len(listed))
+	for _, resource := range listed {
+		name := ""
+		for _, key := range []string{"name", "code", "email", "reference"} {
+			if value, ok := resource.Attributes[key].(string); ok {
+				name = exportName(value)
+			}
+			if name != "" {
+				break
+			}
+		}
+		if name == "" {
+			name = exportName(resource.Id)
+		}
+
+		unique := name
+		for i := 2; used[unique]; i++ {
+			unique = fmt.Sprintf("%s_%d", name, i)
+		}
+		used[unique] = true
+
+		resources = append(resources, exportedResource{tfType: tfType, name: unique, resource: resource})
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].name < resources[j].name
+	})
+	return resources
+}
+
+// exportName turns a value into a resource name, which must start with a letter or underscore.
+func exportName(value string) string {
+	name := strings.Trim(exportNamePattern.ReplaceAllString(strings.ToLower(value), "_"), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// renderExport renders a file per resource type, the import blocks that adopt the resources and the variables for
+// their secrets.
+func renderExport(resources []exportedResource) (map[string][]byte, error) {
+	references := map[string]hcl.Traversal{}
+	for _, r := range resources {
+		references[r.resource.Id] = hcl.Traversal{
+			hcl.TraverseRoot{Name: r.tfType},
+			hcl.TraverseAttr{Name: r.name},
+			hcl.TraverseAttr{Name: "id"},
+		}
+	}
+
+	files := map[string]*hclwrite.File{}
+	variables := hclwrite.NewEmptyFile()
+	for _, r := range resources {
+		fileName := strings.TrimPrefix(r.tfType, "commercelayer_") + ".tf"
+		file, ok := files[fileName]
+		if !ok {
+			file = hclwrite.NewEmptyFile()
+			files[fileName] = file
+		} else {
+			file.Body().AppendNewline()
+		}
+
+		block := file.Body().AppendNewBlock("resource", []string{r.tfType, r.name})
+		secrets, err := renderExportedResource(block.Body(), r, references)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", r.tfType, r.name, err)
+		}
+
+		for _, secret := range secrets {
+			if len(variables.Body().Blocks()) > 0 {
+				variables.Body().AppendNewline()
+			}
+			variable := variables.Body().AppendNewBlock("variable", []string{secret}).Body()
+			variable.SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+			variable.SetAttributeValue("sensitive", cty.True)
+		}
+
+	}
+
+	rendered := map[string][]byte{}
+	for fileName, file := range files {
+		rendered[fileName] = file.Bytes()
+	}
+	if len(resources) > 0 {
+		rendered["imports.tf"] = renderImportBlocks(resources)
+	}
+	if len(variables.Body().Blocks()) > 0 {
+		rendered["variables.tf"] = variables.Bytes()
+	}
+
+	return rendered, nil
+}
+
+// renderImportBlocks renders an import block for every resource.
+func renderImportBlocks(resources []exportedResource) []byte {
+	file := hclwrite.NewEmptyFile()
+	for i, r := range resources {
+		if i > 0 {
+			file.Body().AppendNewline()
+		}
+		block := file.Body().AppendNewBlock("import", nil).Body()
+		block.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: r.tfType},
+			hcl.TraverseAttr{Name: r.name},
+		})
+		block.SetAttributeValue("id", cty.StringVal(r.resource.Id))
+	}
+	return file.Bytes()
+}
+
+// renderExportedResource sets the attributes and relationships Commerce Layer returned for a resource, and returns the
+// variables its secrets are read from.
+func renderExportedResource(body *hclwrite.Body, r exportedResource,
+	references map[string]hcl.Traversal) ([]string, error) {
+	attributes := blockSchema(baseResourceMap[r.tfType], "attributes")
+
+	var secrets []string
+	for _, key := range sortedKeys(attributes) {
+		s := attributes[key]
+		value, ok := r.resource.Attributes[key]
+		if key == "enabled" {
+			value, ok = r.resource.Attributes["disabled_at"] == nil, true
+		}
+
+		if s.Sensitive || secretFields()[key] {
+			if s.Required || (ok && value != nil && value != "") {
+				secret := strings.TrimPrefix(r.tfType, "commercelayer_") + "_" + r.name + "_" + key
+				body.SetAttributeTraversal(key, hcl.Traversal{
+					hcl.TraverseRoot{Name: "var"},
+					hcl.TraverseAttr{Name: secret},
+				})
+				secrets = append(secrets, secret)
+			}
+			continue
+		}
+
+		if !ok || value == nil || key == "metadata_json" {
+			continue
+		}
+
+		if key == "metadata" {
+			err := renderExportedMetadata(body, value)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		ctyValue, ok := exportValue(s, value)
+		if !ok || isExportDefault(s, ctyValue) {
+			continue
+		}
+		body.SetAttributeValue(key, ctyValue)
+	}
+
+	for _, key := range sortedKeys(blockSchema(baseResourceMap[r.tfType], "relationships")) {
+		if name, ok := strings.CutSuffix(key, "_ids"); ok {
+			var ids []struct {
+				Id string `json:"id"`
+			}
+			_ = json.Unmarshal(r.resource.Relationships[name+"s"].Data, &ids)
+			if len(ids) == 0 {
+				continue
+			}
+
+			elements := make([]hclwrite.Tokens, 0, len(ids))
+			for _, id := range ids {
+				elements = append(elements, exportReference(id.Id, references))
+			}
+			body.SetAttributeRaw(key, hclwrite.TokensForTuple(elements))
+		} else if name, ok := strings.CutSuffix(key, "_id"); ok {
+			if id := r.resource.relationshipId(name); id != "" {
+				body.SetAttributeRaw(key, exportReference(id, references))
+			}
+		}
+	}
+
+	return secrets, nil
+}
+
+// renderExportedMetadata sets metadata as a map when all values are strings, and as a jsonencode expression for
+// metadata_json otherwise.
+func renderExportedMetadata(body *hclwrite.Body, value any) error {
+	metadata, ok := value.(map[string]any)
+	if !ok || len(metadata) == 0 {
+		return nil
+	}
+
+	strs := map[string]cty.Value{}
+	for key, v := range metadata {
+		if s, ok := v.(string); ok {
+			strs[key] = cty.StringVal(s)
+		}
+	}
+	if len(strs) == len(metadata) {
+		body.SetAttributeValue("metadata", cty.MapVal(strs))
+		return nil
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	impliedType, err := ctyjson.ImpliedType(raw)
+	if err != nil {
+		return err
+	}
+	ctyValue, err := ctyjson.Unmarshal(raw, impliedType)
+	if err != nil {
+		return err
+	}
+	body.SetAttributeRaw("metadata_json", hclwrite.TokensForFunctionCall("jsonencode",
+		hclwrite.TokensForValue(ctyValue)))
+	return nil
+}
+
+// exportReference refers to an exported resource by its id, or writes the id when the resource is not exported.
+func exportReference(id string, references map[string]hcl.Traversal) hclwrite.Tokens {
+	if traversal, ok := references[id]; ok {
+		return hclwrite.TokensForTraversal(traversal)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(id))
+}
+
+// exportValue converts a decoded attribute to the type of its schema. Nested blocks are not exported, as Commerce
+// Layer does not return them as attributes.
+func exportValue(s *schema.Schema, value any) (cty.Value, bool) {
+	switch s.Type {
+	case schema.TypeString:
+		if _, ok := value.(map[string]any); ok {
+			return cty.NilVal, false
+		}
+		return cty.StringVal(fmt.Sprint(value)), true
+	case schema.TypeInt, schema.TypeFloat:
+		number, ok := value.(json.Number)
+		if !ok {
+			return cty.NilVal, false
+		}
+		ctyValue, err := cty.ParseNumberVal(number.String())
+		return ctyValue, err == nil
+	case schema.TypeBool:
+		b, ok := value.(bool)
+		return cty.BoolVal(b), ok
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Schema)
+		values, isList := value.([]any)
+		if !ok || !isList || len(values) == 0 {
+			return cty.NilVal, false
+		}
+		elements := make([]cty.Value, 0, len(values))
+		for _, v := range values {
+			element, ok := exportValue(elem, v)
+			if !ok {
+				return cty.NilVal, false
+			}
+			elements = append(elements, element)
+		}
+		return cty.TupleVal(elements), true
+	}
+	return cty.NilVal, false
+}
+
+// isExportDefault reports whether a value is the default of its schema, which is left out of the configuration.
+func isExportDefault(s *schema.Schema, value cty.Value) bool {
+	var defaultValue cty.Value
+	switch d := s.Default.(type) {
+	case string:
+		defaultValue = cty.StringVal(d)
+	case bool:
+		defaultValue = cty.BoolVal(d)
+	case int:
+		defaultValue = cty.NumberIntVal(int64(d))
+	case float64:
+		defaultValue = cty.NumberFloatVal(d)
+	default:
+		return false
+	}
+	return value.Type().Equals(defaultValue.Type()) && value.Equals(defaultValue).True()
+}
+
+// blockSchema returns the fields of the attributes or relationships block of a resource.
+func blockSchema(r *schema.Resource, block string) map[string]*schema.Schema {
+	s, ok := r.Schema[block]
+	if !ok {
+		return nil
+	}
+	return s.Elem.(*schema.Resource).Schema
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeExportFiles writes the rendered files to a directory, and refuses to overwrite any existing file.
+func writeExportFiles(dir string, files map[string][]byte) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	for _, fileName := range sortedKeys(files) {
+		if _, err := os.Stat(filepath.Join(dir, fileName)); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(dir, fileName))
+		}
+	}
+
+	for _, fileName := range sortedKeys(files) {
+		err := os.WriteFile(filepath.Join(dir, fileName), hclwrite.Format(files[fileName]), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
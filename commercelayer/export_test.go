@@ -0,0 +1,181 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExportServer(t *testing.T, lists map[string][]map[string]any, includes map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := lists[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		includes[r.URL.Path] = r.URL.Query().Get("include")
+
+		w.Header().Set("Content-Type", jsonApiContentType)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "meta": map[string]any{"page_count": 1}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testExportLists() map[string][]map[string]any {
+	return map[string][]map[string]any{
+		"/price_lists": {
+			{
+				"id": "price-list",
+				"attributes": map[string]any{
+					"name":          "EUR Price List",
+					"currency_code": "EUR",
+					"tax_included":  true,
+					"metadata":      map[string]any{"team": "web"},
+				},
+			},
+		},
+		"/markets": {
+			{
+				"id": "market",
+				"attributes": map[string]any{
+					"name":                 "Europe",
+					"code":                 "EU",
+					"shipping_cost_cutoff": json.Number("100"),
+					"disabled_at":          "2024-10-24T16:07:17.169Z",
+					"metadata":             map[string]any{"priority": json.Number("1")},
+				},
+				"relationships": map[string]any{
+					"price_list": testLinkage(priceListType, "price-list"),
+					"merchant":   testLinkage(merchantType, "not-exported"),
+				},
+			},
+			{
+				"id":         "1abc",
+				"attributes": map[string]any{"name": "Europe", "disabled_at": nil},
+			},
+		},
+		"/adyen_gateways": {
+			{
+				"id":         "adyen",
+				"attributes": map[string]any{"name": "Adyen", "merchant_account": "account", "async_api": true},
+			},
+		},
+	}
+}
+
+func TestExportOrganization(t *testing.T) {
+	includes := map[string]string{}
+	server := testExportServer(t, testExportLists(), includes)
+
+	files, err := exportOrganization(context.Background(), testApiClient(server.URL), []string{
+		"commercelayer_price_list", "commercelayer_market", "commercelayer_adyen_gateway",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "base_price_list,customer_group,geocoder,inventory_model,merchant,price_list,"+
+		"subscription_model,tax_calculator", includes["/markets"])
+	assert.Equal(t, "", includes["/price_lists"])
+
+	assert.Equal(t, `resource "commercelayer_price_list" "eur_price_list" {
+  currency_code = "EUR"
+  metadata = {
+    team = "web"
+  }
+  name = "EUR Price List"
+}
+`, string(files["price_list.tf"]))
+
+	assert.Equal(t, `resource "commercelayer_market" "europe" {
+  code    = "EU"
+  enabled = false
+  metadata_json = jsonencode({
+    priority = 1
+  })
+  name                 = "Europe"
+  shipping_cost_cutoff = 100
+  merchant_id          = "not-exported"
+  price_list_id        = commercelayer_price_list.eur_price_list.id
+}
+
+resource "commercelayer_market" "europe_2" {
+  name = "Europe"
+}
+`, string(files["market.tf"]))
+
+	assert.Equal(t, `resource "commercelayer_adyen_gateway" "adyen" {
+  api_key          = var.adyen_gateway_adyen_api_key
+  async_api        = true
+  merchant_account = "account"
+  name             = "Adyen"
+}
+`, string(files["adyen_gateway.tf"]))
+
+	assert.Contains(t, string(files["imports.tf"]), `import {
+  to = commercelayer_market.europe_2
+  id = "1abc"
+}`)
+
+	assert.Equal(t, `variable "adyen_gateway_adyen_api_key" {
+  type      = string
+  sensitive = true
+}
+`, string(files["variables.tf"]))
+}
+
+func TestExportOrganizationUnknownType(t *testing.T) {
+	_, err := exportOrganization(context.Background(), testApiClient(""), []string{"commercelayer_order"})
+	assert.EqualError(t, err, "commercelayer_order can't be exported")
+}
+
+func TestExportOrganizationTooManyResources(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		data := make([]map[string]any, jsonApiPageSize)
+		for i := range data {
+			data[i] = map[string]any{"id": fmt.Sprintf("address-%d", requests*jsonApiPageSize+i),
+				"attributes": map[string]any{"line_1": "Street"}}
+		}
+
+		w.Header().Set("Content-Type", jsonApiContentType)
+		pageCount := exportMaxPages + 1
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": data,
+			"meta": map[string]any{"page_count": pageCount, "record_count": pageCount * jsonApiPageSize},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := exportOrganization(context.Background(), testApiClient(server.URL), []string{"commercelayer_address"})
+	assert.EqualError(t, err, "commercelayer_address: 10025 resources match, more than the 10000 that are read for a "+
+		"resource type")
+	assert.Equal(t, exportMaxPages+1, requests)
+}
+
+func TestExportName(t *testing.T) {
+	assert.Equal(t, "eur_price_list", exportName("EUR Price List"))
+	assert.Equal(t, "john_doe_example_com", exportName("John.Doe@example.com"))
+	assert.Equal(t, "_1abc", exportName("1abc"))
+	assert.Equal(t, "", exportName("---"))
+}
+
+func TestWriteExportFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "export")
+
+	require.NoError(t, writeExportFiles(dir, map[string][]byte{"market.tf": []byte("resource \"a\" \"b\" {\nx=1\n}\n")}))
+	content, err := os.ReadFile(filepath.Join(dir, "market.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "resource \"a\" \"b\" {\n  x = 1\n}\n", string(content))
+
+	err = writeExportFiles(dir, map[string][]byte{"market.tf": nil})
+	assert.ErrorContains(t, err, "market.tf already exists")
+}
@@ -0,0 +1,1011 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeApiToken           = "fake-access-token"
+	fakeApiDefaultPageSize = 10
+	fakeApiIdLetters       = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// fakeApiTypes are the resource types the fake API serves, which are the types of types.go.
+var fakeApiTypes = []string{
+	addressType, merchantType, customerGroupType, priceListType, webhookType, externalGatewayType,
+	externalTaxCalculatorType, marketType, inventoryModelType, shippingMethodType, shippingZoneType,
+	shippingCategoryType, stockLocationType, inventoryReturnLocationsType, inventoryStockLocationsType,
+	deliveryLeadTimesType, googleGeocodersType, bingGeocodersType, paymentMethodType, manualGatewaysType,
+	adyenGatewaysType, paypalGatewaysType, klarnaGatewaysType, braintreeGatewaysType, checkoutComGatewaysType,
+	stripeGatewaysType, satispayGatewaysType, axerveGatewaysType, manualTaxCalculatorsType, taxjarAccountsType,
+	subscriptionModelsType, customersType, customerAddressesType, tagsType, priceListSchedulersType, pricesType,
+	eventCallbackType,
+}
+
+// fakeApiSubtypes are the polymorphic types, like geocoders, whose endpoints return the resources of their subtypes.
+// They can't be created themselves.
+var fakeApiSubtypes = map[string][]string{
+	geocoderType:      {googleGeocodersType, bingGeocodersType},
+	taxCalculatorType: {externalTaxCalculatorType, manualTaxCalculatorsType, taxjarAccountsType},
+	paymentGatewayType: {
+		adyenGatewaysType, axerveGatewaysType, braintreeGatewaysType, checkoutComGatewaysType, externalGatewayType,
+		klarnaGatewaysType, manualGatewaysType, paypalGatewaysType, satispayGatewaysType, stripeGatewaysType,
+	},
+}
+
+// fakeApiUniqueAttributes are the attributes Commerce Layer requires to be unique within an organization.
+var fakeApiUniqueAttributes = map[string][]string{
+	marketType:    {"code"},
+	customersType: {"email"},
+}
+
+// fakeApiPredicates are the Ransack predicates the fake API filters on. Longer predicates come first, as eq is a
+// suffix of not_eq, gteq and lteq.
+var fakeApiPredicates = []string{
+	"not_eq", "not_in", "present", "gteq", "lteq", "start", "null", "cont", "end", "eq", "gt", "lt", "in",
+}
+
+// fakeApi is an in-process fake of the Commerce Layer API, so the acceptance tests run without an organization or
+// recorded responses. It keeps the resources in memory and implements the JSON:API behaviour the provider relies on:
+// CRUD with generated ids, relationships and includes, filters, sorting and pagination, and the 404, 422 and 429
+// errors of Commerce Layer.
+type fakeApi struct {
+	*httptest.Server
+
+	// rateLimitEvery makes every nth API request fail with a 429 response, zero disables rate limiting.
+	rateLimitEvery int
+
+	mutex     sync.Mutex
+	resources map[string]*fakeApiResource
+	requests  int
+	sequence  int
+}
+
+type fakeApiResource struct {
+	id            string
+	resourceType  string
+	attributes    map[string]any
+	relationships map[string]fakeApiRelationship
+	sequence      int
+}
+
+type fakeApiRelationship struct {
+	toMany  bool
+	linkage []fakeApiLinkage
+}
+
+type fakeApiLinkage struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type fakeApiError struct {
+	Title  string            `json:"title"`
+	Detail string            `json:"detail"`
+	Code   string            `json:"code"`
+	Status string            `json:"status"`
+	Source map[string]string `json:"source,omitempty"`
+}
+
+// fakeApiRequest is the document of a POST or PATCH request.
+type fakeApiRequest struct {
+	Data struct {
+		Id            string                     `json:"id"`
+		Type          string                     `json:"type"`
+		Attributes    map[string]any             `json:"attributes"`
+		Relationships map[string]json.RawMessage `json:"relationships"`
+	} `json:"data"`
+}
+
+// newFakeApi starts a fake API that is closed when the test ends. The API endpoint is at /api and the auth endpoint
+// at /oauth/token.
+func newFakeApi(t testing.TB) *fakeApi {
+	f := &fakeApi{resources: map[string]*fakeApiResource{}}
+	f.Server = httptest.NewServer(f)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// add stores a resource without validating it, for the resources the provider reads but can't create, like event
+// callbacks. The relationships map relationship names to the ids of stored resources.
+func (f *fakeApi) add(resourceType string, attributes map[string]any, relationships map[string]string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	resource := f.newResource(resourceType)
+	for key, value := range attributes {
+		resource.attributes[key] = value
+	}
+	for name, id := range relationships {
+		resource.relationships[name] = fakeApiRelationship{
+			linkage: []fakeApiLinkage{{Type: f.resources[id].resourceType, Id: id}},
+		}
+	}
+	return resource.id
+}
+
+func (f *fakeApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.URL.Path == "/oauth/token" {
+		f.token(w, r)
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/api/")
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "NOT_FOUND", "Not found", r.URL.Path+" is not an API path", "")
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+fakeApiToken {
+		f.writeError(w, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid token",
+			"The access token you provided is invalid.", "")
+		return
+	}
+
+	f.requests++
+	if f.rateLimitEvery > 0 && f.requests%f.rateLimitEvery == 0 {
+		w.Header().Set("X-Ratelimit-Interval", "0.01")
+		f.writeError(w, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", "Too many requests",
+			"You have exceeded the rate limit, please retry later.", "")
+		return
+	}
+
+	segments := strings.Split(path, "/")
+	if !f.knownType(segments[0]) || len(segments) > 2 {
+		f.writeError(w, http.StatusNotFound, "NOT_FOUND", "Not found", path+" is not a resource path", "")
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		f.list(w, r, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		f.create(w, r, segments[0])
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		f.get(w, r, segments[0], segments[1])
+	case len(segments) == 2 && r.Method == http.MethodPatch:
+		f.update(w, r, segments[0], segments[1])
+	case len(segments) == 2 && r.Method == http.MethodDelete:
+		f.delete(w, segments[0], segments[1])
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed",
+			r.Method+" is not allowed on "+path, "")
+	}
+}
+
+// token hands out an access token for any client credentials.
+func (f *fakeApi) token(w http.ResponseWriter, r *http.Request) {
+	clientId, _, ok := r.BasicAuth()
+	if err := r.ParseForm(); err == nil && r.PostForm.Get("client_id") != "" {
+		clientId, ok = r.PostForm.Get("client_id"), true
+	}
+	if r.Method != http.MethodPost || !ok || clientId == "" {
+		f.writeError(w, http.StatusUnauthorized, "INVALID_CLIENT", "Invalid client",
+			"Client authentication failed.", "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": fakeApiToken,
+		"token_type":   "bearer",
+		"expires_in":   14400,
+		"scope":        "market:all",
+		"created_at":   time.Now().Unix(),
+	})
+}
+
+func (f *fakeApi) list(w http.ResponseWriter, r *http.Request, resourceType string) {
+	query := r.URL.Query()
+
+	var resources []*fakeApiResource
+	for _, resource := range f.resources {
+		if f.isType(resource, resourceType) && f.matches(resource, query) {
+			resources = append(resources, resource)
+		}
+	}
+	f.sort(resources, query.Get("sort"))
+
+	size, number, ok := fakeApiPage(query)
+	if !ok {
+		f.writeError(w, http.StatusBadRequest, "INVALID_PAGE", "Invalid page",
+			fmt.Sprintf("The page size must be between 1 and %d and the page number at least 1", jsonApiPageSize),
+			"")
+		return
+	}
+	pageCount := int(math.Ceil(float64(len(resources)) / float64(size)))
+	page := resources[min((number-1)*size, len(resources)):min(number*size, len(resources))]
+
+	f.writeDocument(w, http.StatusOK, page, true, query.Get("include"), map[string]any{
+		"record_count": len(resources),
+		"page_count":   pageCount,
+	})
+}
+
+func (f *fakeApi) get(w http.ResponseWriter, r *http.Request, resourceType string, id string) {
+	resource, ok := f.find(w, resourceType, id)
+	if !ok {
+		return
+	}
+
+	f.writeDocument(w, http.StatusOK, []*fakeApiResource{resource}, false, r.URL.Query().Get("include"), nil)
+}
+
+func (f *fakeApi) create(w http.ResponseWriter, r *http.Request, resourceType string) {
+	if _, ok := fakeApiSubtypes[resourceType]; ok {
+		f.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed",
+			resourceType+" can't be created, create one of its subtypes instead", "")
+		return
+	}
+
+	request, ok := f.decodeRequest(w, r, resourceType, "")
+	if !ok {
+		return
+	}
+
+	resource := &fakeApiResource{
+		resourceType:  resourceType,
+		attributes:    map[string]any{"metadata": map[string]any{}},
+		relationships: map[string]fakeApiRelationship{},
+	}
+	errs := f.apply(resource, request)
+	for _, key := range fakeApiRequiredAttributes(resourceType) {
+		if fakeApiBlank(resource.attributes[key]) {
+			errs = append(errs, fakeApiValidationError(key, "can't be blank"))
+		}
+	}
+	if len(errs) > 0 {
+		f.writeErrors(w, http.StatusUnprocessableEntity, errs)
+		return
+	}
+
+	stored := f.newResource(resourceType)
+	for key, value := range resource.attributes {
+		stored.attributes[key] = value
+	}
+	stored.relationships = resource.relationships
+	f.computeAttributes(stored)
+
+	f.writeDocument(w, http.StatusCreated, []*fakeApiResource{stored}, false, "", nil)
+}
+
+func (f *fakeApi) update(w http.ResponseWriter, r *http.Request, resourceType string, id string) {
+	resource, ok := f.find(w, resourceType, id)
+	if !ok {
+		return
+	}
+
+	request, ok := f.decodeRequest(w, r, resource.resourceType, id)
+	if !ok {
+		return
+	}
+
+	updated := &fakeApiResource{
+		id:            resource.id,
+		resourceType:  resource.resourceType,
+		attributes:    map[string]any{},
+		relationships: map[string]fakeApiRelationship{},
+	}
+	for key, value := range resource.attributes {
+		updated.attributes[key] = value
+	}
+	for name, relationship := range resource.relationships {
+		updated.relationships[name] = relationship
+	}
+
+	errs := f.apply(updated, request)
+	for _, key := range fakeApiRequiredAttributes(resource.resourceType) {
+		if _, ok := request.Data.Attributes[key]; ok && fakeApiBlank(updated.attributes[key]) {
+			errs = append(errs, fakeApiValidationError(key, "can't be blank"))
+		}
+	}
+	if len(errs) > 0 {
+		f.writeErrors(w, http.StatusUnprocessableEntity, errs)
+		return
+	}
+
+	resource.attributes = updated.attributes
+	resource.relationships = updated.relationships
+	resource.attributes["updated_at"] = fakeApiTimestamp()
+	f.computeAttributes(resource)
+
+	f.writeDocument(w, http.StatusOK, []*fakeApiResource{resource}, false, "", nil)
+}
+
+func (f *fakeApi) delete(w http.ResponseWriter, resourceType string, id string) {
+	resource, ok := f.find(w, resourceType, id)
+	if !ok {
+		return
+	}
+
+	delete(f.resources, resource.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeRequest decodes the document of a POST or PATCH request, which must be for the resource of the path.
+func (f *fakeApi) decodeRequest(w http.ResponseWriter, r *http.Request, resourceType string,
+	id string) (fakeApiRequest, bool) {
+	var request fakeApiRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&request); err != nil {
+		f.writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Bad request", err.Error(), "")
+		return request, false
+	}
+
+	if request.Data.Type != resourceType || request.Data.Id != id {
+		f.writeError(w, http.StatusConflict, "CONFLICT", "Conflict",
+			fmt.Sprintf("The document is for %s %s, not for %s %s", request.Data.Type, request.Data.Id,
+				resourceType, id), "/data")
+		return request, false
+	}
+
+	return request, true
+}
+
+// apply sets the attributes and relationships of a request on a resource, and returns the validation errors.
+// Attributes starting with an underscore are triggers, like _disable, that change other attributes.
+func (f *fakeApi) apply(resource *fakeApiResource, request fakeApiRequest) []fakeApiError {
+	var errs []fakeApiError
+	for key, value := range request.Data.Attributes {
+		if trigger, ok := strings.CutPrefix(key, "_"); ok {
+			if value == true {
+				f.trigger(resource, trigger)
+			}
+			continue
+		}
+
+		if slices.Contains(fakeApiUniqueAttributes[resource.resourceType], key) && !fakeApiBlank(value) {
+			for _, other := range f.resources {
+				if other.resourceType == resource.resourceType && other.id != resource.id &&
+					fmt.Sprint(other.attributes[key]) == fmt.Sprint(value) {
+					errs = append(errs, fakeApiValidationError(key, "has already been taken"))
+					break
+				}
+			}
+		}
+		resource.attributes[key] = value
+	}
+
+	for name, raw := range request.Data.Relationships {
+		var relationship struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &relationship); err != nil {
+			errs = append(errs, fakeApiRelationshipError(name, err.Error()))
+			continue
+		}
+
+		var linkage []fakeApiLinkage
+		toMany := strings.HasPrefix(strings.TrimSpace(string(relationship.Data)), "[")
+		if toMany {
+			_ = json.Unmarshal(relationship.Data, &linkage)
+		} else if string(relationship.Data) != "null" {
+			var single fakeApiLinkage
+			_ = json.Unmarshal(relationship.Data, &single)
+			linkage = []fakeApiLinkage{single}
+		}
+
+		for _, l := range linkage {
+			target, ok := f.resources[l.Id]
+			if !ok || !f.isType(target, l.Type) {
+				errs = append(errs, fakeApiRelationshipError(name, fmt.Sprintf("%s %s not found", l.Type, l.Id)))
+			}
+		}
+		resource.relationships[name] = fakeApiRelationship{toMany: toMany, linkage: linkage}
+	}
+
+	return errs
+}
+
+func (f *fakeApi) trigger(resource *fakeApiResource, trigger string) {
+	switch trigger {
+	case "disable":
+		resource.attributes["disabled_at"] = fakeApiTimestamp()
+	case "enable":
+		resource.attributes["disabled_at"] = nil
+	case "reset_circuit":
+		resource.attributes["circuit_state"] = webhookCircuitClosed
+		resource.attributes["circuit_failure_count"] = 0
+	}
+}
+
+// computeAttributes sets the attributes Commerce Layer computes for some resource types.
+func (f *fakeApi) computeAttributes(resource *fakeApiResource) {
+	switch resource.resourceType {
+	case marketType:
+		resource.attributes["private"] = len(resource.relationships["customer_group"].linkage) > 0
+		if resource.attributes["shared_secret"] == nil {
+			resource.attributes["shared_secret"] = fakeApiSecret()
+		}
+	case webhookType:
+		if resource.attributes["shared_secret"] == nil {
+			resource.attributes["shared_secret"] = fakeApiSecret()
+			resource.attributes["circuit_state"] = webhookCircuitClosed
+			resource.attributes["circuit_failure_count"] = 0
+		}
+	}
+}
+
+func (f *fakeApi) newResource(resourceType string) *fakeApiResource {
+	id := make([]byte, 10)
+	for {
+		for i := range id {
+			id[i] = fakeApiIdLetters[rand.IntN(len(fakeApiIdLetters))]
+		}
+		if _, ok := f.resources[string(id)]; !ok {
+			break
+		}
+	}
+
+	f.sequence++
+	now := fakeApiTimestamp()
+	resource := &fakeApiResource{
+		id:            string(id),
+		resourceType:  resourceType,
+		attributes:    map[string]any{"created_at": now, "updated_at": now, "metadata": map[string]any{}},
+		relationships: map[string]fakeApiRelationship{},
+		sequence:      f.sequence,
+	}
+	f.resources[resource.id] = resource
+	return resource
+}
+
+// find returns the resource of a path, or writes a 404 response.
+func (f *fakeApi) find(w http.ResponseWriter, resourceType string, id string) (*fakeApiResource, bool) {
+	resource, ok := f.resources[id]
+	if !ok || !f.isType(resource, resourceType) {
+		f.writeError(w, http.StatusNotFound, "RECORD_NOT_FOUND", "Record not found",
+			fmt.Sprintf("The requested resource %s %s was not found", resourceType, id), "")
+		return nil, false
+	}
+	return resource, true
+}
+
+func (f *fakeApi) knownType(resourceType string) bool {
+	_, polymorphic := fakeApiSubtypes[resourceType]
+	return polymorphic || slices.Contains(fakeApiTypes, resourceType)
+}
+
+// isType reports whether a resource is of a type, or of one of its subtypes.
+func (f *fakeApi) isType(resource *fakeApiResource, resourceType string) bool {
+	return resource.resourceType == resourceType || slices.Contains(fakeApiSubtypes[resourceType], resource.resourceType)
+}
+
+// matches reports whether a resource matches all filter[q] predicates of a query.
+func (f *fakeApi) matches(resource *fakeApiResource, query map[string][]string) bool {
+	for key, values := range query {
+		condition, ok := strings.CutPrefix(key, "filter[q][")
+		if !ok {
+			continue
+		}
+		condition = strings.TrimSuffix(condition, "]")
+
+		for _, predicate := range fakeApiPredicates {
+			field, ok := strings.CutSuffix(condition, "_"+predicate)
+			if !ok {
+				continue
+			}
+			if !fakeApiPredicate(predicate, f.field(resource, field), values[0]) {
+				return false
+			}
+			break
+		}
+	}
+	return true
+}
+
+// field returns an attribute of a resource for filters and sorting, or the id of a to-one relationship for fields
+// like market_id.
+func (f *fakeApi) field(resource *fakeApiResource, field string) any {
+	if field == "id" {
+		return resource.id
+	}
+	if value, ok := resource.attributes[field]; ok {
+		return value
+	}
+	if name, ok := strings.CutSuffix(field, "_id"); ok {
+		if relationship := resource.relationships[name]; len(relationship.linkage) > 0 {
+			return relationship.linkage[0].Id
+		}
+	}
+	return nil
+}
+
+// sort sorts resources by the fields of a sort parameter, like -created_at, and in order of creation otherwise.
+func (f *fakeApi) sort(resources []*fakeApiResource, fields string) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].sequence < resources[j].sequence
+	})
+
+	for _, field := range slices.Backward(strings.Split(fields, ",")) {
+		if field == "" {
+			continue
+		}
+		descending := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		sort.SliceStable(resources, func(i, j int) bool {
+			compared := fakeApiCompare(f.field(resources[i], field), f.field(resources[j], field))
+			if descending {
+				return compared > 0
+			}
+			return compared < 0
+		})
+	}
+}
+
+func (f *fakeApi) render(resource *fakeApiResource, included map[string]bool) map[string]any {
+	self := fmt.Sprintf("%s/api/%s/%s", f.URL, resource.resourceType, resource.id)
+
+	attributes := map[string]any{}
+	for key, value := range resource.attributes {
+		attributes[key] = value
+	}
+
+	relationships := map[string]any{}
+	for name, relationship := range resource.relationships {
+		rendered := map[string]any{
+			"links": map[string]string{
+				"self":    self + "/relationships/" + name,
+				"related": self + "/" + name,
+			},
+		}
+		if included[name] {
+			var linkage []fakeApiLinkage
+			for _, l := range relationship.linkage {
+				if target, ok := f.resources[l.Id]; ok {
+					linkage = append(linkage, fakeApiLinkage{Type: target.resourceType, Id: target.id})
+				}
+			}
+			switch {
+			case relationship.toMany:
+				rendered["data"] = append([]fakeApiLinkage{}, linkage...)
+			case len(linkage) > 0:
+				rendered["data"] = linkage[0]
+			default:
+				rendered["data"] = nil
+			}
+		}
+		relationships[name] = rendered
+	}
+
+	return map[string]any{
+		"id":            resource.id,
+		"type":          resource.resourceType,
+		"links":         map[string]string{"self": self},
+		"attributes":    attributes,
+		"relationships": relationships,
+	}
+}
+
+// include collects the resources of the include paths, like market.price_list, of a resource.
+func (f *fakeApi) include(resource *fakeApiResource, paths []string, included map[string]*fakeApiResource) {
+	for _, path := range paths {
+		name, rest, _ := strings.Cut(path, ".")
+		for _, l := range resource.relationships[name].linkage {
+			target, ok := f.resources[l.Id]
+			if !ok {
+				continue
+			}
+			included[target.id] = target
+			if rest != "" {
+				f.include(target, []string{rest}, included)
+			}
+		}
+	}
+}
+
+func (f *fakeApi) writeDocument(w http.ResponseWriter, status int, resources []*fakeApiResource, list bool,
+	include string, meta map[string]any) {
+	var paths []string
+	includedNames := map[string]bool{}
+	if include != "" {
+		paths = strings.Split(include, ",")
+		for _, path := range paths {
+			name, _, _ := strings.Cut(path, ".")
+			includedNames[name] = true
+		}
+	}
+
+	data := make([]any, 0, len(resources))
+	included := map[string]*fakeApiResource{}
+	for _, resource := range resources {
+		data = append(data, f.render(resource, includedNames))
+		f.include(resource, paths, included)
+	}
+
+	document := map[string]any{"data": data}
+	if !list {
+		document["data"] = data[0]
+	}
+	if meta != nil {
+		document["meta"] = meta
+	}
+	if len(included) > 0 {
+		var rendered []any
+		for _, id := range sortedKeys(included) {
+			rendered = append(rendered, f.render(included[id], nil))
+		}
+		document["included"] = rendered
+	}
+
+	w.Header().Set("Content-Type", jsonApiContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(document)
+}
+
+func (f *fakeApi) writeError(w http.ResponseWriter, status int, code string, title string, detail string,
+	pointer string) {
+	err := fakeApiError{Title: title, Detail: detail, Code: code}
+	if pointer != "" {
+		err.Source = map[string]string{"pointer": pointer}
+	}
+	f.writeErrors(w, status, []fakeApiError{err})
+}
+
+func (f *fakeApi) writeErrors(w http.ResponseWriter, status int, errs []fakeApiError) {
+	for i := range errs {
+		errs[i].Status = strconv.Itoa(status)
+	}
+
+	w.Header().Set("Content-Type", jsonApiContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": errs})
+}
+
+// fakeApiRequiredAttributes returns the attributes that are required in the schema of the resource for a type.
+func fakeApiRequiredAttributes(resourceType string) []string {
+	var required []string
+	for _, exported := range exportResourceTypes {
+		if exported.apiType != resourceType {
+			continue
+		}
+		for key, field := range blockSchema(baseResourceMap[exported.tfType], "attributes") {
+			if field.Required {
+				required = append(required, key)
+			}
+		}
+	}
+	sort.Strings(required)
+	return required
+}
+
+func fakeApiPage(query map[string][]string) (int, int, bool) {
+	size, number := fakeApiDefaultPageSize, 1
+	var err error
+	if value := fakeApiQueryValue(query, "page[size]"); value != "" {
+		if size, err = strconv.Atoi(value); err != nil || size < 1 || size > jsonApiPageSize {
+			return 0, 0, false
+		}
+	}
+	if value := fakeApiQueryValue(query, "page[number]"); value != "" {
+		if number, err = strconv.Atoi(value); err != nil || number < 1 {
+			return 0, 0, false
+		}
+	}
+	return size, number, true
+}
+
+func fakeApiQueryValue(query map[string][]string, key string) string {
+	if values := query[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func fakeApiPredicate(predicate string, value any, operand string) bool {
+	switch predicate {
+	case "eq":
+		return value != nil && fmt.Sprint(value) == operand
+	case "not_eq":
+		return value == nil || fmt.Sprint(value) != operand
+	case "in":
+		return value != nil && slices.Contains(strings.Split(operand, ","), fmt.Sprint(value))
+	case "not_in":
+		return value == nil || !slices.Contains(strings.Split(operand, ","), fmt.Sprint(value))
+	case "cont":
+		return value != nil && strings.Contains(strings.ToLower(fmt.Sprint(value)), strings.ToLower(operand))
+	case "start":
+		return value != nil && strings.HasPrefix(fmt.Sprint(value), operand)
+	case "end":
+		return value != nil && strings.HasSuffix(fmt.Sprint(value), operand)
+	case "null":
+		return (value == nil) == (operand == "true")
+	case "present":
+		return fakeApiBlank(value) != (operand == "true")
+	case "gt":
+		return value != nil && fakeApiCompare(value, operand) > 0
+	case "gteq":
+		return value != nil && fakeApiCompare(value, operand) >= 0
+	case "lt":
+		return value != nil && fakeApiCompare(value, operand) < 0
+	case "lteq":
+		return value != nil && fakeApiCompare(value, operand) <= 0
+	}
+	return false
+}
+
+// fakeApiCompare compares two values as numbers when both are numbers, as strings otherwise. Timestamps compare as
+// strings, as they share a format. Nil sorts first.
+func fakeApiCompare(a any, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	aNumber, aErr := strconv.ParseFloat(fmt.Sprint(a), 64)
+	bNumber, bErr := strconv.ParseFloat(fmt.Sprint(b), 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func fakeApiBlank(value any) bool {
+	return value == nil || value == ""
+}
+
+func fakeApiValidationError(key string, message string) fakeApiError {
+	return fakeApiError{
+		Title:  message,
+		Detail: key + " - " + message,
+		Code:   "VALIDATION_ERROR",
+		Source: map[string]string{"pointer": "/data/attributes/" + key},
+	}
+}
+
+func fakeApiRelationshipError(name string, message string) fakeApiError {
+	return fakeApiError{
+		Title:  message,
+		Detail: name + " - " + message,
+		Code:   "VALIDATION_ERROR",
+		Source: map[string]string{"pointer": "/data/relationships/" + name},
+	}
+}
+
+func fakeApiTimestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func fakeApiSecret() string {
+	return fmt.Sprintf("%016x%016x", rand.Uint64(), rand.Uint64())
+}
+
+// client returns an api client for the fake API, configured like the provider configures it.
+func (f *fakeApi) client(t testing.TB) *apiClient {
+	c, err := newConfiguration().newApiClient(providerSettings{
+		clientId:        "client-id",
+		clientSecret:    "client-secret",
+		apiEndpoint:     f.URL + "/api",
+		authEndpoint:    f.URL + "/oauth/token",
+		rateLimiter:     true,
+		pollInterval:    "1ms",
+		maxPollInterval: "4ms",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestFakeApiCrud(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+	ctx := context.Background()
+
+	created, _, err := c.PriceListsApi.POSTPriceLists(ctx).PriceListCreate(commercelayer.PriceListCreate{
+		Data: commercelayer.PriceListCreateData{
+			Type: priceListType,
+			Attributes: commercelayer.POSTPriceLists201ResponseDataAttributes{
+				Name:         "EUR Price List",
+				CurrencyCode: "EUR",
+			},
+		},
+	}).Execute()
+	require.NoError(t, err)
+	id := created.Data.GetId().(string)
+	assert.Len(t, id, 10)
+
+	err = patchResource(ctx, c, priceListType, id, map[string]any{"name": "Euro Price List"}, nil)
+	require.NoError(t, err)
+
+	resource, _, err := getJsonApiResource(ctx, c, priceListType, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Euro Price List", resource.Attributes["name"])
+	assert.Equal(t, "EUR", resource.Attributes["currency_code"])
+
+	_, err = c.PriceListsApi.DELETEPriceListsPriceListId(ctx, id).Execute()
+	require.NoError(t, err)
+
+	_, _, err = getJsonApiResource(ctx, c, priceListType, id)
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestFakeApiList(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+	ctx := context.Background()
+
+	inventoryModel := f.add(inventoryModelType, map[string]any{"name": "Europe"}, nil)
+	for i := range 30 {
+		f.add(inventoryStockLocationsType, map[string]any{"priority": i}, map[string]string{
+			"inventory_model": inventoryModel,
+		})
+	}
+	f.add(inventoryStockLocationsType, map[string]any{"priority": 1}, nil)
+
+	count, err := countJsonApiResources(ctx, c, inventoryStockLocationsType, url.Values{
+		"filter[q][inventory_model_id_eq]": {inventoryModel},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30, count)
+
+	var priorities []any
+	err = listJsonApiResources(ctx, c, inventoryStockLocationsType, url.Values{
+		"filter[q][inventory_model_id_eq]": {inventoryModel},
+		"filter[q][priority_gteq]":         {"10"},
+		"sort":                             {"-priority"},
+		"include":                          {"inventory_model"},
+	}, 10, func(page []jsonApiResource) bool {
+		for _, resource := range page {
+			priorities = append(priorities, resource.Attributes["priority"])
+			assert.Equal(t, inventoryModel, resource.relationshipId("inventory_model"))
+		}
+		return true
+	})
+	require.NoError(t, err)
+	require.Len(t, priorities, 20)
+	assert.Equal(t, json.Number("29"), priorities[0])
+	assert.Equal(t, json.Number("10"), priorities[19])
+
+	_, err = doJsonApiPathRequest(ctx, c, inventoryStockLocationsType, url.Values{"page[size]": {"26"}})
+	assert.ErrorContains(t, err, "400 Bad Request")
+}
+
+func TestFakeApiPolymorphicTypes(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+	ctx := context.Background()
+
+	geocoder := f.add(googleGeocodersType, map[string]any{"name": "Google"}, nil)
+
+	resource, _, err := getJsonApiResource(ctx, c, geocoderType, geocoder)
+	require.NoError(t, err)
+	assert.Equal(t, googleGeocodersType, resource.Type)
+
+	_, _, err = getJsonApiResource(ctx, c, bingGeocodersType, geocoder)
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestFakeApiValidation(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+	ctx := context.Background()
+
+	f.add(marketType, map[string]any{"name": "Europe", "code": "EU"}, nil)
+
+	body := []byte(`{"data":{"type":"markets","attributes":{"code":"EU"},` +
+		`"relationships":{"price_list":{"data":{"type":"price_lists","id":"unknown"}}}}}`)
+	resp, respBody, err := sendJsonApiPathRequest(ctx, c, http.MethodPost, marketType, nil, body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var document struct {
+		Errors []fakeApiError `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(respBody, &document))
+	var details []string
+	for _, e := range document.Errors {
+		details = append(details, e.Detail)
+	}
+	assert.ElementsMatch(t, []string{
+		"code - has already been taken",
+		"name - can't be blank",
+		"price_list - price_lists unknown not found",
+	}, details)
+}
+
+func TestFakeApiTriggers(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+	ctx := context.Background()
+
+	market := f.add(marketType, map[string]any{"name": "Europe"}, nil)
+
+	require.NoError(t, patchEnabled(ctx, c, marketType, market, false))
+	resource, _, err := getJsonApiResource(ctx, c, marketType, market)
+	require.NoError(t, err)
+	assert.NotNil(t, resource.Attributes["disabled_at"])
+	assert.NotContains(t, resource.Attributes, "_disable")
+
+	require.NoError(t, patchEnabled(ctx, c, marketType, market, true))
+	resource, _, err = getJsonApiResource(ctx, c, marketType, market)
+	require.NoError(t, err)
+	assert.Nil(t, resource.Attributes["disabled_at"])
+}
+
+func TestFakeApiRateLimiting(t *testing.T) {
+	f := newFakeApi(t)
+	f.rateLimitEvery = 2
+	c := f.client(t)
+	ctx := context.Background()
+
+	market := f.add(marketType, map[string]any{"name": "Europe"}, nil)
+	for range 4 {
+		_, _, err := getJsonApiResource(ctx, c, marketType, market)
+		require.NoError(t, err)
+	}
+
+	c.GetConfig().HTTPClient.Transport = c.GetConfig().HTTPClient.Transport.(*throttledTransport).transport
+	resp, _, err := sendJsonApiRequest(ctx, c, http.MethodGet, marketType, market, nil)
+	require.NoError(t, err)
+	if resp.StatusCode == http.StatusOK {
+		resp, _, err = sendJsonApiRequest(ctx, c, http.MethodGet, marketType, market, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "0.01", resp.Header.Get("X-Ratelimit-Interval"))
+}
+
+func TestFakeApiUnauthorized(t *testing.T) {
+	f := newFakeApi(t)
+
+	resp, _, err := sendJsonApiRequest(context.Background(), testApiClient(f.URL+"/api"), http.MethodGet,
+		marketType, "market", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestFakeApiMarketReadiness(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+
+	priceList := f.add(priceListType, map[string]any{"name": "EUR", "currency_code": "EUR"}, nil)
+	inventoryModel := f.add(inventoryModelType, map[string]any{"name": "Europe"}, nil)
+	market := f.add(marketType, map[string]any{"name": "Europe"}, map[string]string{
+		"price_list":      priceList,
+		"inventory_model": inventoryModel,
+	})
+
+	problems, err := checkMarketReadiness(context.Background(), c, market)
+	require.NoError(t, err)
+	var checks []string
+	for _, problem := range problems {
+		checks = append(checks, problem.check)
+	}
+	assert.Equal(t, []string{"price_list", "payment_methods", "shipping_methods", "inventory_model",
+		"tax_calculator"}, checks)
+}
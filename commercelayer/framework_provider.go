@@ -0,0 +1,184 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider is the terraform-plugin-framework half of the provider. It is served next to the SDKv2 provider
+// through a mux server, so new resources can be written on the framework while the existing ones keep running on the
+// SDKv2. Both halves share the provider schema and configure the same api client.
+type frameworkProvider struct {
+	configuration *Configuration
+}
+
+var (
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+type frameworkProviderModel struct {
+	ClientId           types.String `tfsdk:"client_id"`
+	ClientSecret       types.String `tfsdk:"client_secret"`
+	ApiEndpoint        types.String `tfsdk:"api_endpoint"`
+	AuthEndpoint       types.String `tfsdk:"auth_endpoint"`
+	RateLimiter        types.Bool   `tfsdk:"rate_limiter"`
+	PollInterval       types.String `tfsdk:"poll_interval"`
+	MaxPollInterval    types.String `tfsdk:"max_poll_interval"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+func FrameworkProvider(opts ...ProviderOption) func() provider.Provider {
+	c := newConfiguration(opts...)
+
+	return func() provider.Provider {
+		return &frameworkProvider{configuration: c}
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "commercelayer"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = frameworkProviderSchema()
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest,
+	resp *provider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := providerSettings{
+		clientId:           stringSetting(config.ClientId, "client_id", &resp.Diagnostics),
+		clientSecret:       stringSetting(config.ClientSecret, "client_secret", &resp.Diagnostics),
+		apiEndpoint:        stringSetting(config.ApiEndpoint, "api_endpoint", &resp.Diagnostics),
+		authEndpoint:       stringSetting(config.AuthEndpoint, "auth_endpoint", &resp.Diagnostics),
+		rateLimiter:        boolSetting(config.RateLimiter, "rate_limiter", &resp.Diagnostics),
+		pollInterval:       stringSetting(config.PollInterval, "poll_interval", &resp.Diagnostics),
+		maxPollInterval:    stringSetting(config.MaxPollInterval, "max_poll_interval", &resp.Diagnostics),
+		deletionProtection: boolSetting(config.DeletionProtection, "deletion_protection", &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := p.configuration.newApiClient(settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider configuration", err.Error())
+		return
+	}
+
+	resp.ResourceData = client
+	resp.DataSourceData = client
+	resp.EphemeralResourceData = client
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newMarketReadinessDataSource,
+		newWebhookEventCallbacksDataSource,
+	}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newAccessTokenEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newCentsFunction,
+		newAmountFunction,
+		newZoneMatchesFunction,
+		newWebhookSignatureFunction,
+	}
+}
+
+// stringSetting returns a string provider setting. Settings that were left out of the configuration fall back to the
+// default of the SDKv2 schema, so both halves read the same environment variables.
+func stringSetting(value types.String, key string, diags *diag.Diagnostics) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+
+	defaultValue := settingDefault(key, diags)
+	if defaultValue == nil {
+		return ""
+	}
+	return fmt.Sprint(defaultValue)
+}
+
+// boolSetting returns a boolean provider setting, see stringSetting.
+func boolSetting(value types.Bool, key string, diags *diag.Diagnostics) bool {
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+
+	defaultValue, _ := settingDefault(key, diags).(bool)
+	return defaultValue
+}
+
+func settingDefault(key string, diags *diag.Diagnostics) interface{} {
+	defaultValue, err := baseSchema[key].DefaultValue()
+	if err != nil {
+		diags.AddAttributeError(path.Root(key), "Invalid provider configuration", err.Error())
+	}
+	return defaultValue
+}
+
+// frameworkProviderSchema converts the SDKv2 provider schema, as the mux server requires both halves to report the
+// same provider schema. Like the SDKv2, a required setting is reported as optional when its default, usually an
+// environment variable, provides a value.
+func frameworkProviderSchema() schema.Schema {
+	attributes := make(map[string]schema.Attribute, len(baseSchema))
+	for key, s := range baseSchema {
+		required := s.Required
+		if required && s.DefaultFunc != nil {
+			value, err := s.DefaultFunc()
+			if err != nil || value != nil {
+				required = false
+			}
+		}
+
+		switch s.Type {
+		case sdkschema.TypeString:
+			attributes[key] = schema.StringAttribute{
+				Description: s.Description,
+				Required:    required,
+				Optional:    !required,
+				Sensitive:   s.Sensitive,
+			}
+		case sdkschema.TypeBool:
+			attributes[key] = schema.BoolAttribute{
+				Description: s.Description,
+				Required:    required,
+				Optional:    !required,
+				Sensitive:   s.Sensitive,
+			}
+		default:
+			panic(fmt.Sprintf("unsupported provider setting type %s for %s", s.Type, key))
+		}
+	}
+
+	return schema.Schema{Attributes: attributes}
+}
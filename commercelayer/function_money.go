@@ -0,0 +1,176 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/ladydascalie/currency"
+)
+
+// numberPrecision is the precision Terraform uses for numbers. Amounts are built from their decimal notation with it,
+// so that they compare equal to the same amount written in the configuration.
+const numberPrecision = 512
+
+type centsFunction struct{}
+
+var _ function.Function = &centsFunction{}
+
+func newCentsFunction() function.Function {
+	return &centsFunction{}
+}
+
+func (f *centsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cents"
+}
+
+func (f *centsFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts an amount to cents",
+		Description: "Converts an amount to the minor units of its currency, which Commerce Layer uses for all " +
+			"prices and amounts. The number of minor units follows ISO 4217, so 19.99 EUR is 1999 and 1999 JPY is " +
+			"1999. Amounts with more decimals than the currency has minor units are rejected.",
+		Parameters: []function.Parameter{
+			function.NumberParameter{
+				Name:        "amount",
+				Description: "The amount in the major unit of the currency.",
+			},
+			function.StringParameter{
+				Name:        "currency",
+				Description: "The ISO 4217 code of the currency, for example EUR.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *centsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var amount *big.Float
+	var currencyCode string
+	resp.Error = req.Arguments.Get(ctx, &amount, &currencyCode)
+	if resp.Error != nil {
+		return
+	}
+
+	minorUnits, err := currencyMinorUnits(currencyCode)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	cents, err := centsFromAmount(amount, minorUnits)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%s: %s", currencyCode, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, cents)
+}
+
+type amountFunction struct{}
+
+var _ function.Function = &amountFunction{}
+
+func newAmountFunction() function.Function {
+	return &amountFunction{}
+}
+
+func (f *amountFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "amount"
+}
+
+func (f *amountFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts cents to an amount",
+		Description: "Converts an amount in the minor units of its currency, as Commerce Layer returns them, to the " +
+			"major unit of the currency. The number of minor units follows ISO 4217, so 1999 EUR is 19.99 and 1999 " +
+			"JPY is 1999.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "cents",
+				Description: "The amount in the minor units of the currency.",
+			},
+			function.StringParameter{
+				Name:        "currency",
+				Description: "The ISO 4217 code of the currency, for example EUR.",
+			},
+		},
+		Return: function.NumberReturn{},
+	}
+}
+
+func (f *amountFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cents int64
+	var currencyCode string
+	resp.Error = req.Arguments.Get(ctx, &cents, &currencyCode)
+	if resp.Error != nil {
+		return
+	}
+
+	minorUnits, err := currencyMinorUnits(currencyCode)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	amount, err := amountFromCents(cents, minorUnits)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, amount)
+}
+
+func currencyMinorUnits(code string) (int, error) {
+	c, err := currency.Get(code)
+	if err != nil {
+		return 0, err
+	}
+	return c.MinorUnits(), nil
+}
+
+// centsFromAmount converts an amount to minor units. The conversion works on the decimal notation of the amount, as
+// multiplying the binary floating point value would turn 19.99 into 1998.999...
+func centsFromAmount(amount *big.Float, minorUnits int) (int64, error) {
+	if amount.IsInf() {
+		return 0, fmt.Errorf("amount must be finite")
+	}
+
+	whole, fraction, _ := strings.Cut(amount.Text('f', -1), ".")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > minorUnits {
+		return 0, fmt.Errorf("amount %s has more decimals than the %d minor units of the currency",
+			amount.Text('f', -1), minorUnits)
+	}
+
+	cents, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", minorUnits-len(fraction)), 10)
+	if !ok || !cents.IsInt64() {
+		return 0, fmt.Errorf("amount %s is out of range", amount.Text('f', -1))
+	}
+
+	return cents.Int64(), nil
+}
+
+// amountFromCents converts minor units to an amount, parsed from its decimal notation like Terraform parses numbers.
+func amountFromCents(cents int64, minorUnits int) (*big.Float, error) {
+	digits := new(big.Int).Abs(big.NewInt(cents)).String()
+	if len(digits) <= minorUnits {
+		digits = strings.Repeat("0", minorUnits-len(digits)+1) + digits
+	}
+
+	decimal := digits
+	if minorUnits > 0 {
+		decimal = digits[:len(digits)-minorUnits] + "." + digits[len(digits)-minorUnits:]
+	}
+	if cents < 0 {
+		decimal = "-" + decimal
+	}
+
+	amount, _, err := big.ParseFloat(decimal, 10, numberPrecision, big.ToNearestEven)
+	return amount, err
+}
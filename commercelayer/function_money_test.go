@@ -0,0 +1,111 @@
+package commercelayer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAmount(t *testing.T, amount string) *big.Float {
+	f, _, err := big.ParseFloat(amount, 10, numberPrecision, big.ToNearestEven)
+	require.NoError(t, err)
+	return f
+}
+
+func TestCentsFromAmount(t *testing.T) {
+	for amount, expected := range map[string]int64{
+		"19.99":  1999,
+		"19.9":   1990,
+		"19":     1900,
+		"0.01":   1,
+		"-12.50": -1250,
+		"1.10":   110,
+	} {
+		cents, err := centsFromAmount(testAmount(t, amount), 2)
+		require.NoError(t, err, amount)
+		assert.Equal(t, expected, cents, amount)
+	}
+
+	cents, err := centsFromAmount(testAmount(t, "1999"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1999), cents)
+
+	cents, err = centsFromAmount(testAmount(t, "1.234"), 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), cents)
+}
+
+func TestCentsFromAmountErr(t *testing.T) {
+	_, err := centsFromAmount(testAmount(t, "19.999"), 2)
+	assert.Error(t, err)
+
+	_, err = centsFromAmount(testAmount(t, "19.5"), 0)
+	assert.Error(t, err)
+
+	_, err = centsFromAmount(testAmount(t, "1e30"), 2)
+	assert.Error(t, err)
+}
+
+func TestAmountFromCents(t *testing.T) {
+	for _, tc := range []struct {
+		cents      int64
+		minorUnits int
+		expected   string
+	}{
+		{1999, 2, "19.99"},
+		{5, 2, "0.05"},
+		{-1250, 2, "-12.5"},
+		{1999, 0, "1999"},
+		{1234, 3, "1.234"},
+		{0, 2, "0"},
+	} {
+		amount, err := amountFromCents(tc.cents, tc.minorUnits)
+		require.NoError(t, err)
+		assert.Zero(t, testAmount(t, tc.expected).Cmp(amount), tc.expected)
+	}
+}
+
+func TestCentsFunction(t *testing.T) {
+	resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+	newCentsFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.NumberValue(testAmount(t, "19.99")),
+			types.StringValue("EUR"),
+		}),
+	}, &resp)
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.Int64Value(1999), resp.Result.Value())
+}
+
+func TestCentsFunctionInvalidCurrency(t *testing.T) {
+	resp := function.RunResponse{Result: function.NewResultData(types.Int64Unknown())}
+	newCentsFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.NumberValue(testAmount(t, "19.99")),
+			types.StringValue("XYZ"),
+		}),
+	}, &resp)
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, int64(1), *resp.Error.FunctionArgument)
+}
+
+func TestAmountFunction(t *testing.T) {
+	resp := function.RunResponse{Result: function.NewResultData(types.NumberUnknown())}
+	newAmountFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.Int64Value(1999),
+			types.StringValue("JPY"),
+		}),
+	}, &resp)
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.NumberValue(testAmount(t, "1999")), resp.Result.Value())
+}
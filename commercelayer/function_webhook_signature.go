@@ -0,0 +1,62 @@
+package commercelayer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type webhookSignatureFunction struct{}
+
+var _ function.Function = &webhookSignatureFunction{}
+
+func newWebhookSignatureFunction() function.Function {
+	return &webhookSignatureFunction{}
+}
+
+func (f *webhookSignatureFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "webhook_signature"
+}
+
+func (f *webhookSignatureFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Computes the signature of a webhook payload",
+		Description: "Computes the signature Commerce Layer sends in the X-CommerceLayer-Signature header of a " +
+			"webhook callback: the base64 encoded HMAC-SHA256 of the body, keyed with the shared secret of the " +
+			"webhook. Useful to test webhook consumers with signed payloads.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "secret",
+				Description: "The shared secret of the webhook.",
+			},
+			function.StringParameter{
+				Name: "body",
+				Description: "The exact body of the callback. Pass the same string that is sent to the consumer, " +
+					"any difference in formatting changes the signature.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *webhookSignatureFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secret, body string
+	resp.Error = req.Arguments.Get(ctx, &secret, &body)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, webhookSignature(secret, body))
+}
+
+// webhookSignature computes the signature Commerce Layer sends with webhook callbacks.
+func webhookSignature(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
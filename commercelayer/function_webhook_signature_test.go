@@ -0,0 +1,12 @@
+package commercelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	assert.Equal(t, "97yD9DBThCSxMpjmqm+xQ+9NWaFJRhdZl0edvC0aPNg=",
+		webhookSignature("key", "The quick brown fox jumps over the lazy dog"))
+}
@@ -0,0 +1,159 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type zoneMatchesFunction struct{}
+
+var _ function.Function = &zoneMatchesFunction{}
+
+func newZoneMatchesFunction() function.Function {
+	return &zoneMatchesFunction{}
+}
+
+func (f *zoneMatchesFunction) Metadata(_ context.Context, _ function.MetadataRequest,
+	resp *function.MetadataResponse) {
+	resp.Name = "zone_matches"
+}
+
+func (f *zoneMatchesFunction) Definition(_ context.Context, _ function.DefinitionRequest,
+	resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a shipping zone matches an address",
+		Description: "Checks whether an address falls within a shipping zone, the way Commerce Layer matches " +
+			"shipping addresses with shipping zones. Every regex that is set must match the corresponding code of " +
+			"the address, and every negative regex that is set must not match it. Regexes are not anchored, so " +
+			"use ^ and $ to match a whole code. Commerce Layer evaluates the regexes as Ruby regexes, while this " +
+			"function evaluates them with Go's RE2 syntax. Regexes that use syntax only one of them supports, " +
+			"like lookarounds and backreferences, are rejected with the same error as the regexes of a " +
+			"commercelayer_shipping_zone resource.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "zone_regexes",
+				Description: "An object or map with the country_code_regex, not_country_code_regex, " +
+					"state_code_regex, not_state_code_regex, zip_code_regex and not_zip_code_regex of the zone. " +
+					"Missing and null regexes are not evaluated, so a commercelayer_shipping_zone resource can be " +
+					"passed as is.",
+			},
+			function.StringParameter{
+				Name:        "country",
+				Description: "The country code of the address.",
+			},
+			function.StringParameter{
+				Name:           "state",
+				Description:    "The state code of the address, null is treated as an empty code.",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "zip",
+				Description:    "The zip code of the address, null is treated as an empty code.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *zoneMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var zoneRegexes types.Dynamic
+	var country string
+	var state, zip types.String
+	resp.Error = req.Arguments.Get(ctx, &zoneRegexes, &country, &state, &zip)
+	if resp.Error != nil {
+		return
+	}
+
+	regexes, err := zoneRegexesFromValue(zoneRegexes.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	matches, err := shippingZoneMatches(regexes, country, state.ValueString(), zip.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, matches)
+}
+
+// shippingZoneRegexKeys are the attributes a shipping zone matches addresses with.
+var shippingZoneRegexKeys = []string{
+	"country_code_regex",
+	"not_country_code_regex",
+	"state_code_regex",
+	"not_state_code_regex",
+	"zip_code_regex",
+	"not_zip_code_regex",
+}
+
+// zoneRegexesFromValue reads the shipping zone regexes from an object or a map. Other attributes are ignored, so a
+// whole shipping zone resource can be passed.
+func zoneRegexesFromValue(value attr.Value) (map[string]string, error) {
+	var values map[string]attr.Value
+	switch v := value.(type) {
+	case types.Object:
+		values = v.Attributes()
+	case types.Map:
+		values = v.Elements()
+	default:
+		return nil, fmt.Errorf("zone_regexes must be an object or a map, got %s", value.Type(context.Background()))
+	}
+
+	regexes := map[string]string{}
+	for _, key := range shippingZoneRegexKeys {
+		regex, ok := values[key]
+		if !ok || regex.IsNull() {
+			continue
+		}
+
+		s, ok := regex.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, fmt.Errorf("%s must be a known string", key)
+		}
+		regexes[key] = s.ValueString()
+	}
+
+	return regexes, nil
+}
+
+// shippingZoneMatches reports whether an address matches the regexes of a shipping zone. Empty regexes are not
+// evaluated, like Commerce Layer skips the regexes that are not set. The regexes are matched with RE2 semantics, so they
+// are first checked to be in the syntax that Ruby and Go share, as shipping zones check them.
+func shippingZoneMatches(regexes map[string]string, countryCode string, stateCode string,
+	zipCode string) (bool, error) {
+	codes := map[string]string{
+		"country_code_regex": countryCode,
+		"state_code_regex":   stateCode,
+		"zip_code_regex":     zipCode,
+	}
+
+	matches := true
+	for _, key := range shippingZoneRegexKeys {
+		pattern := regexes[key]
+		if pattern == "" {
+			continue
+		}
+
+		err := validateShippingZoneRegex(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s %s: %s", key, pattern, err)
+		}
+
+		codeKey, negative := strings.CutPrefix(key, "not_")
+		if regexp.MustCompile(pattern).MatchString(codes[codeKey]) == negative {
+			matches = false
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,95 @@
+package commercelayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippingZoneMatches(t *testing.T) {
+	regexes := map[string]string{
+		"country_code_regex":     "^(NL|BE)$",
+		"not_country_code_regex": "^BE$",
+		"zip_code_regex":         "^[0-9]{4}",
+		"not_zip_code_regex":     "^1000",
+	}
+
+	for _, tc := range []struct {
+		country  string
+		zip      string
+		expected bool
+	}{
+		{"NL", "3044 BC", true},
+		{"BE", "3044 BC", false},
+		{"DE", "3044 BC", false},
+		{"NL", "1000 AA", false},
+		{"NL", "", false},
+	} {
+		matches, err := shippingZoneMatches(regexes, tc.country, "", tc.zip)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, matches, "%s %s", tc.country, tc.zip)
+	}
+}
+
+func TestShippingZoneMatchesNoRegexes(t *testing.T) {
+	matches, err := shippingZoneMatches(map[string]string{}, "NL", "", "")
+	require.NoError(t, err)
+	assert.True(t, matches)
+}
+
+func TestShippingZoneMatchesErr(t *testing.T) {
+	_, err := shippingZoneMatches(map[string]string{"state_code_regex": "(ZH"}, "NL", "ZH", "")
+	assert.ErrorContains(t, err, "state_code_regex")
+
+	_, err = shippingZoneMatches(map[string]string{"country_code_regex": "^(?!DE)"}, "NL", "", "")
+	assert.ErrorContains(t, err, "invalid country_code_regex ^(?!DE)")
+	assert.ErrorContains(t, err, "lookarounds, atomic groups and possessive quantifiers are not supported")
+
+	_, err = shippingZoneMatches(map[string]string{"zip_code_regex": "(?s)^10"}, "NL", "", "1012")
+	assert.ErrorContains(t, err, "the s, m and U flags are not supported by Ruby")
+}
+
+func TestZoneRegexesFromValue(t *testing.T) {
+	zone := types.ObjectValueMust(
+		map[string]attr.Type{
+			"id":                 types.StringType,
+			"country_code_regex": types.StringType,
+			"zip_code_regex":     types.StringType,
+		},
+		map[string]attr.Value{
+			"id":                 types.StringUnknown(),
+			"country_code_regex": types.StringValue("^NL$"),
+			"zip_code_regex":     types.StringNull(),
+		},
+	)
+
+	regexes, err := zoneRegexesFromValue(zone)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"country_code_regex": "^NL$"}, regexes)
+
+	_, err = zoneRegexesFromValue(types.StringValue("^NL$"))
+	assert.Error(t, err)
+}
+
+func TestZoneMatchesFunction(t *testing.T) {
+	resp := function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+	newZoneMatchesFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
+				"country_code_regex": types.StringValue("^NL$"),
+				"state_code_regex":   types.StringValue("^ZH$"),
+			})),
+			types.StringValue("NL"),
+			types.StringNull(),
+			types.StringValue("3044 BC"),
+		}),
+	}, &resp)
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, types.BoolValue(false), resp.Result.Value())
+}
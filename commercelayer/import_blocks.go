@@ -0,0 +1,54 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// importFilterPredicate matches the Ransack predicates Commerce Layer list endpoints filter on, for example
+// name_cont or reference_eq.
+var importFilterPredicate = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ImportConfig configures the discovery of existing resources of a type.
+type ImportConfig struct {
+	// ResourceType is the resource type to discover, for example commercelayer_stock_location.
+	ResourceType string
+	// Filters are the Ransack predicates and values the resources must match, for example name_cont=Amsterdam.
+	Filters map[string]string
+}
+
+// ImportBlocks lists the existing resources of a type that match the filters and returns an import block for every
+// resource. The credentials are read from the environment variables the provider reads.
+func ImportBlocks(ctx context.Context, config ImportConfig, opts ...ProviderOption) ([]byte, error) {
+	c, err := environmentApiClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return importBlocks(ctx, c, config)
+}
+
+func importBlocks(ctx context.Context, c *apiClient, config ImportConfig) ([]byte, error) {
+	resourceType, err := getExportResourceType(config.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	for _, predicate := range sortedKeys(config.Filters) {
+		if !importFilterPredicate.MatchString(predicate) {
+			return nil, fmt.Errorf("invalid filter %q, filters are predicates like name_eq or reference_cont",
+				predicate)
+		}
+		query.Set(fmt.Sprintf("filter[q][%s]", predicate), config.Filters[predicate])
+	}
+
+	resources, err := listExportedResources(ctx, c, resourceType, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderImportBlocks(resources), nil
+}
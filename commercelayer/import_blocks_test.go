@@ -0,0 +1,63 @@
+package commercelayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportBlocks(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stock_locations", r.URL.Path)
+		query = r.URL.Query()
+
+		w.Header().Set("Content-Type", jsonApiContentType)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"id": "amsterdam", "attributes": map[string]any{"name": "Amsterdam Warehouse"}},
+				{"id": "rotterdam", "attributes": map[string]any{"name": "Amsterdam Warehouse"}},
+			},
+			"meta": map[string]any{"page_count": 1},
+		})
+	}))
+	defer server.Close()
+
+	blocks, err := importBlocks(context.Background(), testApiClient(server.URL), ImportConfig{
+		ResourceType: "commercelayer_stock_location",
+		Filters:      map[string]string{"name_cont": "Amsterdam", "reference_origin_eq": "erp"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Amsterdam", query.Get("filter[q][name_cont]"))
+	assert.Equal(t, "erp", query.Get("filter[q][reference_origin_eq]"))
+	assert.Equal(t, `import {
+  to = commercelayer_stock_location.amsterdam_warehouse
+  id = "amsterdam"
+}
+
+import {
+  to = commercelayer_stock_location.amsterdam_warehouse_2
+  id = "rotterdam"
+}
+`, string(blocks))
+}
+
+func TestImportBlocksInvalidConfig(t *testing.T) {
+	c := testApiClient("http://localhost")
+
+	_, err := importBlocks(context.Background(), c, ImportConfig{ResourceType: "commercelayer_order"})
+	assert.EqualError(t, err, "commercelayer_order can't be exported")
+
+	_, err = importBlocks(context.Background(), c, ImportConfig{
+		ResourceType: "commercelayer_stock_location",
+		Filters:      map[string]string{"name][": "Amsterdam"},
+	})
+	assert.ErrorContains(t, err, `invalid filter "name]["`)
+}
@@ -0,0 +1,394 @@
+package commercelayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const jsonApiContentType = "application/vnd.api+json"
+
+// patchToManyRelationship replaces the linkage of a to-many relationship of a resource. The generated SDK models
+// to-many relationships as a single resource identifier, so these requests are built by hand.
+func patchToManyRelationship(ctx context.Context, c *apiClient, resourceType string, id string,
+	relationship string, relationshipType string, ids []string) error {
+	linkage := make([]map[string]string, 0, len(ids))
+	for _, relationshipId := range ids {
+		linkage = append(linkage, map[string]string{"type": relationshipType, "id": relationshipId})
+	}
+
+	return patchResource(ctx, c, resourceType, id, nil, map[string]any{
+		relationship: map[string]any{"data": linkage},
+	})
+}
+
+// patchToOneRelationship replaces the linkage of a to-one relationship the generated SDK does not know about. A nil
+// id clears the relationship.
+func patchToOneRelationship(ctx context.Context, c *apiClient, resourceType string, id string,
+	relationship string, relationshipType string, relationshipId *string) error {
+	var linkage any
+	if relationshipId != nil {
+		linkage = map[string]string{"type": relationshipType, "id": *relationshipId}
+	}
+
+	return patchResource(ctx, c, resourceType, id, nil, map[string]any{
+		relationship: map[string]any{"data": linkage},
+	})
+}
+
+// clearRemovedRelationships sends an explicit null linkage for the optional to-one relationships that were removed
+// from the configuration. The generated SDK can't express an empty linkage, so leaving them out of the update
+// payload would keep the old relationship in place. The relationships map holds the schema keys and their
+// relationship names.
+func clearRemovedRelationships(ctx context.Context, c *apiClient, d *schema.ResourceData,
+	resourceType string, relationships map[string]string) error {
+	removed := map[string]any{}
+	for key, relationship := range relationships {
+		if hasRelationshipChange(d, key) && stringRef(d.Get(relationshipKey(d, key))) == nil {
+			removed[relationship] = map[string]any{"data": nil}
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	return patchResource(ctx, c, resourceType, d.Id(), nil, removed)
+}
+
+// patchResource sends a hand-built PATCH request for a resource. It is used for attributes and relationships that
+// the generated SDK does not know about; nil attributes or relationships are left out of the payload.
+func patchResource(ctx context.Context, c *apiClient, resourceType string, id string,
+	attributes map[string]any, relationships map[string]any) error {
+	data := map[string]any{
+		"type": resourceType,
+		"id":   id,
+	}
+	if attributes != nil {
+		data["attributes"] = attributes
+	}
+	if relationships != nil {
+		data["relationships"] = relationships
+	}
+
+	body, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return err
+	}
+
+	_, err = doJsonApiRequest(ctx, c, http.MethodPatch, resourceType, id, body)
+	return err
+}
+
+// mergeMetadata merges the metadata changes of the configuration into the metadata Commerce Layer currently holds
+// for a resource, so that keys other systems added are kept.
+func mergeMetadata(ctx context.Context, c *apiClient, d *schema.ResourceData,
+	resourceType string) (map[string]any, error) {
+	body, err := doJsonApiRequest(ctx, c, http.MethodGet, resourceType, d.Id(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		Data struct {
+			Attributes struct {
+				Metadata map[string]any `json:"metadata"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	err = decodeJson(body, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	oldMetadata := map[string]any{
+		"metadata":      stateBlockField(d, "attributes", "metadata"),
+		"metadata_json": stateBlockField(d, "attributes", "metadata_json"),
+	}
+	newMetadata := map[string]any{
+		"metadata":      d.Get(attributeKey(d, "metadata")),
+		"metadata_json": d.Get(attributeKey(d, "metadata_json")),
+	}
+	return mergeMetadataKeys(document.Data.Attributes.Metadata, metadataRef(oldMetadata), metadataRef(newMetadata)), nil
+}
+
+// mergeMetadataKeys applies the difference between the old and new configured metadata to the remote metadata. Keys
+// that were removed from the configuration are removed, all configured keys are set.
+func mergeMetadataKeys(remote map[string]any, oldMetadata map[string]any, newMetadata map[string]any) map[string]any {
+	merged := make(map[string]any, len(remote)+len(newMetadata))
+	for key, value := range remote {
+		merged[key] = value
+	}
+	for key := range oldMetadata {
+		if _, ok := newMetadata[key]; !ok {
+			delete(merged, key)
+		}
+	}
+	for key, value := range newMetadata {
+		merged[key] = value
+	}
+	return merged
+}
+
+// doJsonApiRequest sends a hand-built JSON:API request for a single resource with the client configuration of the
+// generated SDK, and returns the response body.
+func doJsonApiRequest(ctx context.Context, c *apiClient, method string, resourceType string,
+	id string, body []byte) ([]byte, error) {
+	resp, respBody, err := sendJsonApiRequest(ctx, c, method, resourceType, id, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s: %s", resp.Status, string(respBody))
+	}
+
+	return respBody, nil
+}
+
+// sendJsonApiRequest sends a hand-built JSON:API request for a single resource, and returns the response with its
+// body read, whatever its status.
+func sendJsonApiRequest(ctx context.Context, c *apiClient, method string, resourceType string,
+	id string, body []byte) (*http.Response, []byte, error) {
+	return sendJsonApiPathRequest(ctx, c, method, fmt.Sprintf("%s/%s", resourceType, id), nil, body)
+}
+
+// sendJsonApiPathRequest sends a hand-built JSON:API request for a path relative to the API endpoint, and returns the
+// response with its body read, whatever its status.
+func sendJsonApiPathRequest(ctx context.Context, c *apiClient, method string, path string, query url.Values,
+	body []byte) (*http.Response, []byte, error) {
+	serverUrl, err := c.GetConfig().ServerURLWithContext(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	requestUrl := fmt.Sprintf("%s/%s", strings.TrimSuffix(serverUrl, "/"), path)
+	if len(query) > 0 {
+		requestUrl += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", jsonApiContentType)
+	}
+	req.Header.Set("Accept", jsonApiContentType)
+
+	resp, err := c.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, respBody, nil
+}
+
+// jsonApiPageSize is the largest page size Commerce Layer allows for lists.
+const jsonApiPageSize = 25
+
+// jsonApiResource is a resource in a hand-decoded response.
+type jsonApiResource struct {
+	Id            string                         `json:"id"`
+	Type          string                         `json:"type"`
+	Attributes    map[string]any                 `json:"attributes"`
+	Relationships map[string]jsonApiRelationship `json:"relationships"`
+}
+
+// jsonApiRelationship is a relationship of a hand-decoded resource. Commerce Layer only returns the linkage of the
+// relationships that were included.
+type jsonApiRelationship struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// jsonApiDocument is a hand-decoded list response.
+type jsonApiDocument struct {
+	Data     []jsonApiResource `json:"data"`
+	Included []jsonApiResource `json:"included"`
+	Meta     struct {
+		RecordCount int `json:"record_count"`
+		PageCount   int `json:"page_count"`
+	} `json:"meta"`
+}
+
+// relationshipId returns the id of a to-one relationship, or an empty string when it is empty or not included.
+func (r jsonApiResource) relationshipId(relationship string) string {
+	var linkage struct {
+		Id string `json:"id"`
+	}
+	_ = json.Unmarshal(r.Relationships[relationship].Data, &linkage)
+	return linkage.Id
+}
+
+// getJsonApiResource reads a single resource with a hand-built request, together with the included resources.
+func getJsonApiResource(ctx context.Context, c *apiClient, resourceType string, id string,
+	include ...string) (jsonApiResource, []jsonApiResource, error) {
+	query := url.Values{}
+	if len(include) > 0 {
+		query.Set("include", strings.Join(include, ","))
+	}
+
+	body, err := doJsonApiPathRequest(ctx, c, fmt.Sprintf("%s/%s", resourceType, id), query)
+	if err != nil {
+		return jsonApiResource{}, nil, err
+	}
+
+	var document struct {
+		Data     jsonApiResource   `json:"data"`
+		Included []jsonApiResource `json:"included"`
+	}
+	err = decodeJson(body, &document)
+	return document.Data, document.Included, err
+}
+
+// countJsonApiResources counts the resources matching the query, from the record count of a single item page.
+func countJsonApiResources(ctx context.Context, c *apiClient, resourceType string, query url.Values) (int, error) {
+	countQuery := url.Values{}
+	for key, values := range query {
+		countQuery[key] = values
+	}
+	countQuery.Set("page[size]", "1")
+
+	body, err := doJsonApiPathRequest(ctx, c, resourceType, countQuery)
+	if err != nil {
+		return 0, err
+	}
+
+	var document jsonApiDocument
+	err = decodeJson(body, &document)
+	return document.Meta.RecordCount, err
+}
+
+// listJsonApiResources lists resources with a hand-built request, which allows the filters and sorting the generated
+// SDK can't express. The pages are read one by one and passed to the page function until it returns false or the
+// last page is read, at most maxPages pages.
+func listJsonApiResources(ctx context.Context, c *apiClient, resourceType string, query url.Values, maxPages int,
+	page func([]jsonApiResource) bool) error {
+	pageQuery := url.Values{}
+	for key, values := range query {
+		pageQuery[key] = values
+	}
+	pageQuery.Set("page[size]", strconv.Itoa(jsonApiPageSize))
+
+	for number := 1; number <= maxPages; number++ {
+		pageQuery.Set("page[number]", strconv.Itoa(number))
+		body, err := doJsonApiPathRequest(ctx, c, resourceType, pageQuery)
+		if err != nil {
+			return err
+		}
+
+		var document jsonApiDocument
+		err = decodeJson(body, &document)
+		if err != nil {
+			return err
+		}
+
+		if !page(document.Data) || number >= document.Meta.PageCount {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// doJsonApiPathRequest sends a hand-built JSON:API GET request for a path relative to the API endpoint, and returns
+// the response body.
+func doJsonApiPathRequest(ctx context.Context, c *apiClient, path string, query url.Values) ([]byte, error) {
+	resp, body, err := sendJsonApiPathRequest(ctx, c, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s: %s", resp.Status, string(body))
+	}
+
+	return body, nil
+}
+
+// patchEnabled enables or disables a resource through the _enable and _disable trigger attributes, for the
+// resources whose generated SDK models do not expose them.
+func patchEnabled(ctx context.Context, c *apiClient, resourceType string, id string, enabled bool) error {
+	return patchResource(ctx, c, resourceType, id, enabledTrigger(enabled), nil)
+}
+
+// enabledTrigger returns the trigger attribute that moves a resource into the requested enabled state.
+func enabledTrigger(enabled bool) map[string]any {
+	if enabled {
+		return map[string]any{"_enable": true}
+	}
+	return map[string]any{"_disable": true}
+}
+
+// disabledAtFromResponse reads the disabled_at attribute from the raw response of a GET request. The generated SDK
+// leaves the body readable after decoding it, which allows reading attributes its models don't know about.
+func disabledAtFromResponse(resp *http.Response) (interface{}, error) {
+	attributes, err := attributesFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return attributes["disabled_at"], nil
+}
+
+// metadataFromResponse reads the metadata attribute from the raw response of a GET request. Numbers are kept as
+// json.Number, as the generated SDK models would otherwise round large integers through float64.
+func metadataFromResponse(resp *http.Response) (map[string]any, error) {
+	attributes, err := attributesFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, _ := attributes["metadata"].(map[string]any)
+	return metadata, nil
+}
+
+// attributesFromResponse decodes the attributes of the resource in the raw response of a GET request, and resets the
+// body so it can be read again.
+func attributesFromResponse(resp *http.Response) (map[string]any, error) {
+	if resp == nil || resp.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var document struct {
+		Data struct {
+			Attributes map[string]any `json:"attributes"`
+		} `json:"data"`
+	}
+	err = decodeJson(body, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.Data.Attributes, nil
+}
+
+// decodeJson decodes a JSON document with numbers kept as json.Number, so they are written back unchanged.
+func decodeJson(body []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,80 @@
+package commercelayer
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEnabledTrigger(t *testing.T) {
+	assert.Equal(t, map[string]any{"_enable": true}, enabledTrigger(true))
+	assert.Equal(t, map[string]any{"_disable": true}, enabledTrigger(false))
+}
+
+func TestDisabledAtFromResponse(t *testing.T) {
+	body := `{"data":{"id":"xYZkjABcde","attributes":{"disabled_at":"2024-10-24T16:07:17.169Z"}}}`
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+
+	disabledAt, err := disabledAtFromResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-10-24T16:07:17.169Z", disabledAt)
+
+	rest, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(rest))
+}
+
+func TestDisabledAtFromResponseNotDisabled(t *testing.T) {
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(`{"data":{"attributes":{"disabled_at":null}}}`))}
+
+	disabledAt, err := disabledAtFromResponse(resp)
+	assert.NoError(t, err)
+	assert.Nil(t, disabledAt)
+}
+
+func TestDisabledAtFromResponseNil(t *testing.T) {
+	disabledAt, err := disabledAtFromResponse(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, disabledAt)
+}
+
+func TestMergeMetadataKeys(t *testing.T) {
+	remote := map[string]any{"foo": "bar", "removed": "value", "external": "kept"}
+	oldMetadata := map[string]any{"foo": "bar", "removed": "value"}
+	newMetadata := map[string]any{"foo": "baz", "added": "value"}
+
+	assert.Equal(t, map[string]any{"foo": "baz", "added": "value", "external": "kept"},
+		mergeMetadataKeys(remote, oldMetadata, newMetadata))
+}
+
+func TestMergeMetadataKeysNoRemote(t *testing.T) {
+	assert.Equal(t, map[string]any{"foo": "bar"}, mergeMetadataKeys(nil, nil, map[string]any{"foo": "bar"}))
+}
+
+func TestMetadataFromResponse(t *testing.T) {
+	body := `{"data":{"attributes":{"metadata":{"erp":{"id":12345678901234567890}}}}}`
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+
+	metadata, err := metadataFromResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"erp": map[string]any{"id": json.Number("12345678901234567890")}}, metadata)
+}
+
+func TestRelationshipId(t *testing.T) {
+	var resource jsonApiResource
+	err := json.Unmarshal([]byte(`{"id":"xYZkjABcde","relationships":{
+		"market":{"data":{"type":"markets","id":"market"}},
+		"price_list":{"data":null},
+		"tags":{"data":[{"type":"tags","id":"tag"}]},
+		"merchant":{"links":{"self":"https://example.com"}}}}`), &resource)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "market", resource.relationshipId("market"))
+	assert.Equal(t, "", resource.relationshipId("price_list"))
+	assert.Equal(t, "", resource.relationshipId("tags"))
+	assert.Equal(t, "", resource.relationshipId("merchant"))
+	assert.Equal(t, "", resource.relationshipId("unknown"))
+}
@@ -2,6 +2,8 @@ package commercelayer
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -44,6 +46,27 @@ var baseSchema = map[string]*schema.Schema{
 		DefaultFunc: schema.EnvDefaultFunc("COMMERCELAYER_RATE_LIMITER", true),
 		Description: "Enable rate limiting when hitting commerce layer",
 	},
+	"poll_interval": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		DefaultFunc:      schema.EnvDefaultFunc("COMMERCELAYER_POLL_INTERVAL", "1s"),
+		Description:      "The initial interval between polls while waiting for a created or deleted resource",
+		ValidateDiagFunc: durationValidation,
+	},
+	"max_poll_interval": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		DefaultFunc:      schema.EnvDefaultFunc("COMMERCELAYER_MAX_POLL_INTERVAL", "10s"),
+		Description:      "The maximum interval between polls, the poll interval doubles after every poll up to it",
+		ValidateDiagFunc: durationValidation,
+	},
+	"deletion_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		DefaultFunc: schema.EnvDefaultFunc("COMMERCELAYER_DELETION_PROTECTION", false),
+		Description: "The default deletion protection of markets, price lists and payment gateways, which can't be " +
+			"deleted while it is enabled",
+	},
 }
 
 var baseResourceMap = map[string]*schema.Resource{
@@ -72,13 +95,31 @@ var baseResourceMap = map[string]*schema.Resource{
 	"commercelayer_google_geocoder":           resourceGoogleGeocoders(),
 	"commercelayer_bing_geocoder":             resourceBingGeocoders(),
 	"commercelayer_stripe_gateway":            resourceStripeGateway(),
+	"commercelayer_satispay_gateway":          resourceSatispayGateway(),
+	"commercelayer_axerve_gateway":            resourceAxerveGateway(),
 	"commercelayer_payment_method":            resourcePaymentMethod(),
 	"commercelayer_manual_tax_calculator":     resourceManualTaxCalculator(),
 	"commercelayer_taxjar_accounts":           resourceTaxjarAccount(),
+	"commercelayer_subscription_model":        resourceSubscriptionModel(),
+	"commercelayer_customer":                  resourceCustomer(),
+	"commercelayer_customer_address":          resourceCustomerAddress(),
+	"commercelayer_price_list_scheduler":      resourcePriceListScheduler(),
+}
+
+// apiClient is the meta data handed to the resources. It embeds the generated API client and carries the provider
+// settings the resources need.
+type apiClient struct {
+	*api.APIClient
+	credentials        clientcredentials.Config
+	pollInterval       time.Duration
+	maxPollInterval    time.Duration
+	deletionProtection bool
+	transport          http.RoundTripper
 }
 
 type Configuration struct {
 	tokenSource oauth2.TokenSource
+	transport   http.RoundTripper
 }
 
 type ProviderOption func(configuration *Configuration)
@@ -89,12 +130,16 @@ func WithTokenSource(tokenSource oauth2.TokenSource) ProviderOption {
 	}
 }
 
-func Provider(opts ...ProviderOption) plugin.ProviderFunc {
-	c := Configuration{}
-
-	for _, opt := range opts {
-		opt(&c)
+// WithTransport sends the requests of the provider through a transport, including the requests for access tokens.
+// The rate limiter wraps it when it is enabled.
+func WithTransport(transport http.RoundTripper) ProviderOption {
+	return func(c *Configuration) {
+		c.transport = transport
 	}
+}
+
+func Provider(opts ...ProviderOption) plugin.ProviderFunc {
+	c := newConfiguration(opts...)
 
 	return func() *schema.Provider {
 		return &schema.Provider{
@@ -105,21 +150,67 @@ func Provider(opts ...ProviderOption) plugin.ProviderFunc {
 	}
 }
 
+func newConfiguration(opts ...ProviderOption) *Configuration {
+	c := &Configuration{}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// providerSettings holds the provider configuration, as read by either the SDKv2 or the framework provider.
+type providerSettings struct {
+	clientId           string
+	clientSecret       string
+	apiEndpoint        string
+	authEndpoint       string
+	rateLimiter        bool
+	pollInterval       string
+	maxPollInterval    string
+	deletionProtection bool
+}
+
 func (c *Configuration) configureFunc(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	clientId := d.Get("client_id").(string)
-	clientSecret := d.Get("client_secret").(string)
-	apiEndpoint := d.Get("api_endpoint").(string)
-	authEndpoint := d.Get("auth_endpoint").(string)
-	rateLimiter := d.Get("rate_limiter").(bool)
+	client, err := c.newApiClient(providerSettings{
+		clientId:           d.Get("client_id").(string),
+		clientSecret:       d.Get("client_secret").(string),
+		apiEndpoint:        d.Get("api_endpoint").(string),
+		authEndpoint:       d.Get("auth_endpoint").(string),
+		rateLimiter:        d.Get("rate_limiter").(bool),
+		pollInterval:       d.Get("poll_interval").(string),
+		maxPollInterval:    d.Get("max_poll_interval").(string),
+		deletionProtection: d.Get("deletion_protection").(bool),
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
+}
+
+func (c *Configuration) newApiClient(settings providerSettings) (*apiClient, error) {
+	pollInterval, err := time.ParseDuration(settings.pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	maxPollInterval, err := time.ParseDuration(settings.maxPollInterval)
+	if err != nil {
+		return nil, err
+	}
 
 	credentials := clientcredentials.Config{
-		ClientID:     clientId,
-		ClientSecret: clientSecret,
-		TokenURL:     authEndpoint,
+		ClientID:     settings.clientId,
+		ClientSecret: settings.clientSecret,
+		TokenURL:     settings.authEndpoint,
 		Scopes:       []string{},
 	}
 
 	newCtx := context.Background()
+	if c.transport != nil {
+		newCtx = context.WithValue(newCtx, oauth2.HTTPClient, &http.Client{Transport: c.transport})
+	}
 
 	var tokenSource = credentials.TokenSource(newCtx)
 	if c.tokenSource != nil {
@@ -128,7 +219,7 @@ func (c *Configuration) configureFunc(ctx context.Context, d *schema.ResourceDat
 
 	httpClient := oauth2.NewClient(newCtx, tokenSource)
 
-	if rateLimiter {
+	if settings.rateLimiter {
 		httpClient.Transport = &throttledTransport{
 			transport: httpClient.Transport,
 		}
@@ -138,9 +229,24 @@ func (c *Configuration) configureFunc(ctx context.Context, d *schema.ResourceDat
 		HTTPClient: httpClient,
 		Debug:      true,
 		Servers: []api.ServerConfiguration{
-			{URL: apiEndpoint},
+			{URL: settings.apiEndpoint},
 		},
 	})
 
-	return commercelayerClient, nil
+	return &apiClient{
+		APIClient:          commercelayerClient,
+		credentials:        credentials,
+		pollInterval:       pollInterval,
+		maxPollInterval:    maxPollInterval,
+		deletionProtection: settings.deletionProtection,
+		transport:          c.transport,
+	}, nil
+}
+
+// tokenContext returns a context for access token requests, which sends them through the transport of the provider.
+func (c *apiClient) tokenContext(ctx context.Context) context.Context {
+	if c.transport == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: c.transport})
 }
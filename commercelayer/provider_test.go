@@ -3,48 +3,118 @@ package commercelayer
 import (
 	"bytes"
 	"context"
-	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"log"
-	"net/http"
 	"os"
 	"testing"
 	"text/template"
-	"time"
 )
 
 var testAccProviderCommercelayer *schema.Provider
-var testAccProviderFactories = map[string]func() (*schema.Provider, error){}
+var testAccProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){}
 
 type AcceptanceSuite struct {
 	suite.Suite
+	recorder *recorder
 }
 
 func (s *AcceptanceSuite) SetupSuite() {
-	credentials := clientcredentials.Config{
-		ClientID:     os.Getenv("COMMERCELAYER_CLIENT_ID"),
-		ClientSecret: os.Getenv("COMMERCELAYER_CLIENT_SECRET"),
-		TokenURL:     os.Getenv("COMMERCELAYER_AUTH_ENDPOINT"),
-		Scopes:       []string{},
+	var tokenSource oauth2.TokenSource
+	switch mode := os.Getenv(recorderModeEnv); mode {
+	case recorderRecord:
+		s.recorder = newRecorder(mode, recorderCassetteDir)
+	case recorderReplay:
+		s.recorder = newRecorder(mode, recorderCassetteDir)
+		s.useReplayedApi()
+		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: recorderScrubbed})
+	case "":
+		if os.Getenv("COMMERCELAYER_API_ENDPOINT") == "" {
+			s.useFakeApi()
+		}
+	default:
+		log.Fatal(errRecorderMode)
+	}
+
+	if tokenSource == nil {
+		credentials := clientcredentials.Config{
+			ClientID:     os.Getenv("COMMERCELAYER_CLIENT_ID"),
+			ClientSecret: os.Getenv("COMMERCELAYER_CLIENT_SECRET"),
+			TokenURL:     os.Getenv("COMMERCELAYER_AUTH_ENDPOINT"),
+			Scopes:       []string{},
+		}
+
+		token, err := credentials.Token(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		tokenSource = oauth2.StaticTokenSource(token)
+	}
+
+	opts := []ProviderOption{WithTokenSource(tokenSource)}
+	if s.recorder != nil {
+		opts = append(opts, WithTransport(s.recorder))
 	}
 
-	token, err := credentials.Token(context.Background())
+	testAccProviderCommercelayer = Provider(opts...)()
+	providerServer, err := newProviderServer(context.Background(), testAccProviderCommercelayer,
+		FrameworkProvider(opts...)())
 	if err != nil {
 		log.Fatal(err)
 	}
-	tokenSource := oauth2.StaticTokenSource(token)
-
-	testAccProviderCommercelayer = Provider(WithTokenSource(tokenSource))()
-	testAccProviderFactories = map[string]func() (*schema.Provider, error){
-		"commercelayer": func() (*schema.Provider, error) {
-			return testAccProviderCommercelayer, nil
+	testAccProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"commercelayer": func() (tfprotov6.ProviderServer, error) {
+			return providerServer(), nil
 		},
 	}
 }
 
+// BeforeTest starts the cassette of a test when requests are recorded or replayed.
+func (s *AcceptanceSuite) BeforeTest(_ string, testName string) {
+	if s.recorder != nil {
+		s.Require().NoError(s.recorder.start(testName))
+	}
+}
+
+// AfterTest writes the cassette of a test when requests are recorded.
+func (s *AcceptanceSuite) AfterTest(_ string, _ string) {
+	if s.recorder != nil {
+		s.Require().NoError(s.recorder.stop())
+	}
+}
+
+// useFakeApi points the acceptance tests to an in-process fake of the Commerce Layer API, so that they run without an
+// organization. Every 50th request is rate limited to exercise the rate limiter.
+func (s *AcceptanceSuite) useFakeApi() {
+	fake := newFakeApi(s.T())
+	fake.rateLimitEvery = 50
+
+	s.setEnvironment(fake.URL)
+}
+
+// useReplayedApi points the acceptance tests to an endpoint that is never reached, as the recorder replays the
+// responses of the cassettes.
+func (s *AcceptanceSuite) useReplayedApi() {
+	s.setEnvironment("https://replay.commercelayer.invalid")
+}
+
+func (s *AcceptanceSuite) setEnvironment(serverUrl string) {
+	for key, value := range map[string]string{
+		"COMMERCELAYER_CLIENT_ID":         "client-id",
+		"COMMERCELAYER_CLIENT_SECRET":     "client-secret",
+		"COMMERCELAYER_API_ENDPOINT":      serverUrl + "/api",
+		"COMMERCELAYER_AUTH_ENDPOINT":     serverUrl + "/oauth/token",
+		"COMMERCELAYER_POLL_INTERVAL":     "10ms",
+		"COMMERCELAYER_MAX_POLL_INTERVAL": "100ms",
+	} {
+		s.T().Setenv(key, value)
+	}
+}
+
 func TestAcceptanceSuite(t *testing.T) {
 	if os.Getenv("TF_ACC") == "1" {
 		suite.Run(t, new(AcceptanceSuite))
@@ -82,24 +152,7 @@ func hclTemplate(data string, params map[string]any) string {
 	return out.String()
 }
 
-func retryRemoval(times int, callable func() (*http.Response, error)) error {
-	for retries := 1; retries < times; retries++ {
-		resp, err := callable()
-		if resp.StatusCode == 404 {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-
-		if resp.StatusCode == 200 {
-			log.Println("retrying removal")
-			time.Sleep(time.Second)
-			continue
-		}
-
-		return fmt.Errorf("received response code with status %d", resp.StatusCode)
-	}
-
-	return nil
+// testAccWaitForRemoval waits until Commerce Layer no longer returns a destroyed resource, the way deletes wait for it.
+func testAccWaitForRemoval(c *apiClient, rs *terraform.ResourceState) error {
+	return waitForRemoval(context.Background(), c, defaultWaitTimeout, rs.Primary.Attributes["type"], rs.Primary.ID)
 }
@@ -0,0 +1,394 @@
+package commercelayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// recorderModeEnv switches the acceptance tests between recording cassettes against an organization and
+	// replaying them without one.
+	recorderModeEnv = "COMMERCELAYER_RECORDER"
+	recorderRecord  = "record"
+	recorderReplay  = "replay"
+
+	recorderCassetteDir = "testdata/cassettes"
+	recorderScrubbed    = "[scrubbed]"
+)
+
+// recorderOAuthKeys are the keys of the token requests and responses whose values are scrubbed from cassettes, next
+// to the secret fields of the resources.
+var recorderOAuthKeys = []string{"access_token", "refresh_token", "client_id", "client_secret"}
+
+// recorder is an http.RoundTripper that records the requests of a test and their responses to a cassette, or
+// replays a cassette without sending requests. Requests are matched on their method, their path with the query and
+// their normalized body, and every recorded interaction is replayed once, in order.
+type recorder struct {
+	mode      string
+	dir       string
+	transport http.RoundTripper
+
+	mutex    sync.Mutex
+	name     string
+	cassette cassette
+	replayed []bool
+}
+
+type cassette struct {
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+func newRecorder(mode string, dir string) *recorder {
+	return &recorder{mode: mode, dir: dir, transport: http.DefaultTransport}
+}
+
+// start starts recording or replaying the cassette of a test.
+func (r *recorder) start(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.name = name
+	r.cassette = cassette{}
+	r.replayed = nil
+	if r.mode != recorderReplay {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.path())
+	if err != nil {
+		return fmt.Errorf("no cassette to replay for %s, record it with %s=%s: %w", name, recorderModeEnv,
+			recorderRecord, err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return fmt.Errorf("invalid cassette %s: %w", r.path(), err)
+	}
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return nil
+}
+
+// stop writes the recorded cassette of a test.
+func (r *recorder) stop() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.mode != recorderRecord {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path(), append(data, '\n'), 0o644)
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := recorderReadRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := cassetteRequest{
+		Method: req.Method,
+		Path:   recorderPath(req.URL),
+		Body:   recorderNormalize(req.Header.Get("Content-Type"), body),
+	}
+
+	if r.mode == recorderReplay {
+		return r.replay(req, request)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	// Rate limited requests are retried, the retry is what the cassette needs.
+	if resp.StatusCode != http.StatusTooManyRequests {
+		r.mutex.Lock()
+		r.cassette.Interactions = append(r.cassette.Interactions, cassetteInteraction{
+			Request: request,
+			Response: cassetteResponse{
+				Status:      resp.StatusCode,
+				ContentType: resp.Header.Get("Content-Type"),
+				Body:        recorderNormalize(resp.Header.Get("Content-Type"), respBody),
+			},
+		})
+		r.mutex.Unlock()
+	}
+
+	return resp, nil
+}
+
+func (r *recorder) replay(req *http.Request, request cassetteRequest) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request != request {
+			continue
+		}
+		r.replayed[i] = true
+
+		response := interaction.Response
+		header := http.Header{}
+		if response.ContentType != "" {
+			header.Set("Content-Type", response.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+			StatusCode:    response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(response.Body)),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction left in %s for %s %s %s", r.path(), request.Method,
+		request.Path, request.Body)
+}
+
+func (r *recorder) path() string {
+	return filepath.Join(r.dir, r.name+".json")
+}
+
+// recorderReadRequestBody reads the body of a request and puts it back, so it can still be sent.
+func recorderReadRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// recorderPath returns the path of a request with its query sorted, without the host, so that cassettes replay
+// against any endpoint.
+func recorderPath(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Path
+	}
+	return u.Path + "?" + u.Query().Encode()
+}
+
+// recorderNormalize normalizes a JSON or form body with its secrets scrubbed. JSON is re-encoded with sorted keys,
+// so that the order of the keys doesn't matter when requests are matched.
+func recorderNormalize(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err == nil {
+			for key := range form {
+				if recorderSecret(key) {
+					form.Set(key, recorderScrubbed)
+				}
+			}
+			return form.Encode()
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return string(body)
+	}
+	normalized, err := json.Marshal(recorderScrub(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
+
+func recorderSecret(key string) bool {
+	return secretFields()[key] || slices.Contains(recorderOAuthKeys, key)
+}
+
+func recorderScrub(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if s, ok := nested.(string); ok && s != "" && recorderSecret(key) {
+				v[key] = recorderScrubbed
+				continue
+			}
+			v[key] = recorderScrub(nested)
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = recorderScrub(nested)
+		}
+	}
+	return value
+}
+
+// errRecorderMode is returned for an unknown value of the recorder mode environment variable.
+var errRecorderMode = errors.New(recorderModeEnv + " must be " + recorderRecord + " or " + recorderReplay)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	f := newFakeApi(t)
+	dir := t.TempDir()
+	ctx := context.Background()
+	settings := providerSettings{
+		clientId:        "client-id",
+		clientSecret:    "client-secret",
+		apiEndpoint:     f.URL + "/api",
+		authEndpoint:    f.URL + "/oauth/token",
+		pollInterval:    "1ms",
+		maxPollInterval: "4ms",
+	}
+
+	run := func(c *apiClient) (string, string) {
+		body := []byte(`{"data":{"type":"markets","attributes":{"name":"Europe"}}}`)
+		resp, respBody, err := sendJsonApiPathRequest(ctx, c, http.MethodPost, marketType, nil, body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var document struct {
+			Data jsonApiResource `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(respBody, &document))
+
+		_, err = c.MarketsApi.DELETEMarketsMarketId(ctx, document.Data.Id).Execute()
+		require.NoError(t, err)
+		_, _, err = getJsonApiResource(ctx, c, marketType, document.Data.Id)
+		require.ErrorContains(t, err, "404 Not Found")
+
+		sharedSecret, _ := document.Data.Attributes["shared_secret"].(string)
+		return document.Data.Id, sharedSecret
+	}
+
+	recording := newRecorder(recorderRecord, dir)
+	require.NoError(t, recording.start("TestMarket"))
+	c, err := newConfiguration(WithTransport(recording)).newApiClient(settings)
+	require.NoError(t, err)
+	recordedId, sharedSecret := run(c)
+	assert.NotEqual(t, recorderScrubbed, sharedSecret)
+	require.NoError(t, recording.stop())
+
+	data, err := os.ReadFile(filepath.Join(dir, "TestMarket.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), fakeApiToken)
+	assert.NotContains(t, string(data), "client-secret")
+	assert.NotContains(t, string(data), sharedSecret)
+	assert.Contains(t, string(data), `\"shared_secret\":\"[scrubbed]\"`)
+
+	f.Close()
+	replaying := newRecorder(recorderReplay, dir)
+	require.NoError(t, replaying.start("TestMarket"))
+	c, err = newConfiguration(WithTransport(replaying)).newApiClient(settings)
+	require.NoError(t, err)
+	replayedId, sharedSecret := run(c)
+	assert.Equal(t, recordedId, replayedId)
+	assert.Equal(t, recorderScrubbed, sharedSecret)
+
+	_, _, err = getJsonApiResource(ctx, c, marketType, recordedId)
+	assert.ErrorContains(t, err, "no recorded interaction left")
+}
+
+func TestRecorderScrubsCustomerPassword(t *testing.T) {
+	f := newFakeApi(t)
+	dir := t.TempDir()
+
+	recording := newRecorder(recorderRecord, dir)
+	require.NoError(t, recording.start("TestCustomer"))
+	c, err := newConfiguration(WithTransport(recording)).newApiClient(providerSettings{
+		clientId:        "client-id",
+		clientSecret:    "client-secret",
+		apiEndpoint:     f.URL + "/api",
+		authEndpoint:    f.URL + "/oauth/token",
+		pollInterval:    "1ms",
+		maxPollInterval: "4ms",
+	})
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, resourceCustomer().Schema, map[string]interface{}{
+		"email":    "customer@example.com",
+		"password": "super-secret",
+	})
+	diags := resourceCustomer().CreateContext(context.Background(), d, c)
+	require.False(t, diags.HasError(), diags)
+	require.NoError(t, recording.stop())
+
+	data, err := os.ReadFile(filepath.Join(dir, "TestCustomer.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "super-secret")
+	assert.Contains(t, string(data), `\"password\":\"[scrubbed]\"`)
+	assert.Contains(t, string(data), "customer@example.com")
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	r := newRecorder(recorderReplay, t.TempDir())
+
+	err := r.start("TestMissing")
+	assert.ErrorContains(t, err, "no cassette to replay for TestMissing, record it with COMMERCELAYER_RECORDER=record")
+}
+
+func TestRecorderNormalize(t *testing.T) {
+	assert.Equal(t, `{"data":{"attributes":{"api_key":"[scrubbed]","name":"Stripe"},"type":"stripe_gateways"}}`,
+		recorderNormalize(jsonApiContentType,
+			[]byte(`{"data": {"type": "stripe_gateways", "attributes": {"name": "Stripe", "api_key": "sk_live"}}}`)))
+	assert.Equal(t, "client_id=%5Bscrubbed%5D&grant_type=client_credentials",
+		recorderNormalize("application/x-www-form-urlencoded", []byte("grant_type=client_credentials&client_id=id")))
+	assert.Equal(t, "plain", recorderNormalize("text/plain", []byte("plain")))
+	assert.Equal(t, "", recorderNormalize(jsonApiContentType, nil))
+}
+
+func TestRecorderPath(t *testing.T) {
+	u, err := url.Parse("https://example.commercelayer.io/api/markets?page%5Bsize%5D=25&filter%5Bq%5D%5Bcode_eq%5D=EU")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/markets?filter%5Bq%5D%5Bcode_eq%5D=EU&page%5Bsize%5D=25", recorderPath(u))
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceAddress() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Addresses can be associated with orders as their shipping or billing addresses. Add a " +
 			"Google or Bing geocoder to a market if you want its addresses to be automatically geocoded. Customers " +
 			"can save their most-used addresses in their address books (as customer addresses).",
@@ -19,6 +19,7 @@ func resourceAddress() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The address unique identifier",
@@ -30,138 +31,157 @@ func resourceAddress() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"business": {
-							Description: "Indicates if it's a business or a personal address",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-						"first_name": {
-							Description: "Address first name",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"last_name": {
-							Description: "Address last name",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"company": {
-							Description: "Address company name",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"line_1": {
-							Description: "Address line 1, i.e. Street address, PO Box",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"line_2": {
-							Description: "Address line 2, i.e. Apartment, Suite, Building",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"city": {
-							Description: "Address city",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"zip_code": {
-							Description: "ZIP or postal code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"state_code": {
-							Description: "State, province or region code",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"country_code": {
-							Description: "The international 2-letter country code as defined by the ISO 3166-1 standard",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"phone": {
-							Description: "Phone number (including extension).",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"email": {
-							Description: "Email address",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"notes": {
-							Description: "A free notes attached to the address. When used as a shipping address, this " +
-								"can be useful to let the customers add specific delivery instructions.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"lat": {
-							Description: "The address geocoded latitude. This is automatically generated when " +
-								"creating a shipping/billing address for an order and a valid geocoder is attached to " +
-								"the order's market.",
-							Type:     schema.TypeFloat,
-							Optional: true,
-						},
-						"lng": {
-							Description: "The address geocoded longitude. This is automatically generated when " +
-								"creating a shipping/billing address for an order and a valid geocoder is attached " +
-								"to the order's market.",
-							Type:     schema.TypeFloat,
-							Optional: true,
-						},
-						"billing_info": {
-							Description: "Customer's billing information (i.e. VAT number, codice fiscale)",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceAddressAttributes(),
+			"relationships": resourceAddressRelationships(),
+		},
+	})
+}
+
+func resourceAddressAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"business": {
+					Description: "Indicates if it's a business or a personal address",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"first_name": {
+					Description: "Address first name",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"last_name": {
+					Description: "Address last name",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"company": {
+					Description: "Address company name",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"line_1": {
+					Description: "Address line 1, i.e. Street address, PO Box",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"line_2": {
+					Description: "Address line 2, i.e. Apartment, Suite, Building",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"city": {
+					Description: "Address city",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"zip_code": {
+					Description: "ZIP or postal code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"state_code": {
+					Description: "State, province or region code",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"country_code": {
+					Description: "The international 2-letter country code as defined by the ISO 3166-1 standard",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"phone": {
+					Description: "Phone number (including extension).",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"email": {
+					Description: "Email address",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"notes": {
+					Description: "A free notes attached to the address. When used as a shipping address, this " +
+						"can be useful to let the customers add specific delivery instructions.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"lat": {
+					Description: "The address geocoded latitude. This is automatically generated when " +
+						"creating a shipping/billing address for an order and a valid geocoder is attached to " +
+						"the order's market.",
+					Type:     schema.TypeFloat,
+					Optional: true,
+				},
+				"lng": {
+					Description: "The address geocoded longitude. This is automatically generated when " +
+						"creating a shipping/billing address for an order and a valid geocoder is attached " +
+						"to the order's market.",
+					Type:     schema.TypeFloat,
+					Optional: true,
+				},
+				"billing_info": {
+					Description: "Customer's billing information (i.e. VAT number, codice fiscale)",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"geocoder_id": {
-							Description: "The associated geocoder id.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceAddressRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"geocoder_id": {
+					Description: "The associated geocoder id.",
+					Type:        schema.TypeString,
+					Optional:    true,
 				},
 			},
 		},
@@ -169,9 +189,9 @@ func resourceAddress() *schema.Resource {
 }
 
 func resourceAddressReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.AddressesApi.GETAddressesAddressId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.AddressesApi.GETAddressesAddressId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -184,14 +204,24 @@ func resourceAddressReadFunc(ctx context.Context, d *schema.ResourceData, i inte
 
 	d.SetId(address.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceAddressCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceAddressAttributes())
+	relationships := blockValues(d, "relationships", resourceAddressRelationships())
 
 	addressCreate := commercelayer.AddressCreate{
 		Data: commercelayer.AddressCreateData{
@@ -215,7 +245,7 @@ func resourceAddressCreateFunc(ctx context.Context, d *schema.ResourceData, i in
 				BillingInfo:     stringRef(attributes["billing_info"]),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -241,51 +271,60 @@ func resourceAddressCreateFunc(ctx context.Context, d *schema.ResourceData, i in
 
 	d.SetId(address.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, addressType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceAddressDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.AddressesApi.DELETEAddressesAddressId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, addressType)
 	return diag.FromErr(err)
 }
 
 func resourceAddressUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceAddressAttributes())
+	relationships := blockValues(d, "relationships", resourceAddressRelationships())
 
 	var addressUpdate = commercelayer.AddressUpdate{
 		Data: commercelayer.AddressUpdateData{
 			Type: addressType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHAddressesAddressId200ResponseDataAttributes{
-				Business:        boolRef(attributes["business"]),
-				FirstName:       stringRef(attributes["first_name"]),
-				LastName:        stringRef(attributes["last_name"]),
-				Company:         stringRef(attributes["company"]),
-				Line1:           stringRef(attributes["line_1"]),
-				Line2:           stringRef(attributes["line_2"]),
-				City:            stringRef(attributes["city"]),
-				ZipCode:         stringRef(attributes["zip_code"]),
-				StateCode:       stringRef(attributes["state_code"]),
-				CountryCode:     stringRef(attributes["country_code"]),
-				Phone:           stringRef(attributes["phone"]),
-				Email:           stringRef(attributes["email"]),
-				Notes:           stringRef(attributes["notes"]),
-				Lat:             float64ToFloat32Ref(attributes["lat"]),
-				Lng:             float64ToFloat32Ref(attributes["lng"]),
-				BillingInfo:     stringRef(attributes["billing_info"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Business:        changedAttribute(d, "business", boolRef(attributes["business"])),
+				FirstName:       changedAttribute(d, "first_name", stringRef(attributes["first_name"])),
+				LastName:        changedAttribute(d, "last_name", stringRef(attributes["last_name"])),
+				Company:         changedAttribute(d, "company", stringRef(attributes["company"])),
+				Line1:           changedAttribute(d, "line_1", stringRef(attributes["line_1"])),
+				Line2:           changedAttribute(d, "line_2", stringRef(attributes["line_2"])),
+				City:            changedAttribute(d, "city", stringRef(attributes["city"])),
+				ZipCode:         changedAttribute(d, "zip_code", stringRef(attributes["zip_code"])),
+				StateCode:       changedAttribute(d, "state_code", stringRef(attributes["state_code"])),
+				CountryCode:     changedAttribute(d, "country_code", stringRef(attributes["country_code"])),
+				Phone:           changedAttribute(d, "phone", stringRef(attributes["phone"])),
+				Email:           changedAttribute(d, "email", stringRef(attributes["email"])),
+				Notes:           changedAttribute(d, "notes", stringRef(attributes["notes"])),
+				Lat:             changedAttribute(d, "lat", configuredFloat32Ref(d, "lat")),
+				Lng:             changedAttribute(d, "lng", configuredFloat32Ref(d, "lng")),
+				BillingInfo:     changedAttribute(d, "billing_info", stringRef(attributes["billing_info"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
 	geocoderId := stringRef(relationships["geocoder_id"])
-	if geocoderId != nil {
+	if geocoderId != nil && hasRelationshipChange(d, "geocoder_id") {
 		addressUpdate.Data.Relationships.Geocoder = &commercelayer.AddressCreateDataRelationshipsGeocoder{
 			Data: commercelayer.AddressDataRelationshipsGeocoderData{
 				Type: stringRef(geocoderType),
@@ -293,8 +332,25 @@ func resourceAddressUpdateFunc(ctx context.Context, d *schema.ResourceData, i in
 			}}
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, addressType)
+		if err != nil {
+			return diagErr(err)
+		}
+		addressUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.AddressesApi.PATCHAddressesAddressId(ctx, d.Id()).AddressUpdate(addressUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	err = clearRemovedRelationships(ctx, c, d, addressType, map[string]string{
+		"geocoder_id": "geocoder",
+	})
+	if err != nil {
+		return diagErr(err)
+	}
 
+	return nil
 }
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckAddressDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_address" {
@@ -36,34 +35,34 @@ func (s *AcceptanceSuite) TestAccAddress_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckAddressDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckAddressDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccAddressCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", addressType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.business", "true"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.company", "Incentro"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.line_1", "Van Nelleweg 1"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.zip_code", "3044 BC"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.country_code", "NL"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.city", "Rotterdam"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.phone", "+31(0)10 20 20 544"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.state_code", "ZH"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "business", "true"),
+					resource.TestCheckResourceAttr(resourceName, "company", "Incentro"),
+					resource.TestCheckResourceAttr(resourceName, "line_1", "Van Nelleweg 1"),
+					resource.TestCheckResourceAttr(resourceName, "zip_code", "3044 BC"),
+					resource.TestCheckResourceAttr(resourceName, "country_code", "NL"),
+					resource.TestCheckResourceAttr(resourceName, "city", "Rotterdam"),
+					resource.TestCheckResourceAttr(resourceName, "phone", "+31(0)10 20 20 544"),
+					resource.TestCheckResourceAttr(resourceName, "state_code", "ZH"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccAddressUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.line_1", "Moermanskkade 113"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.zip_code", "1013 BC"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.country_code", "NL"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.city", "Amsterdam"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.phone", "020 409 0444"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.state_code", "NH"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "line_1", "Moermanskkade 113"),
+					resource.TestCheckResourceAttr(resourceName, "zip_code", "1013 BC"),
+					resource.TestCheckResourceAttr(resourceName, "country_code", "NL"),
+					resource.TestCheckResourceAttr(resourceName, "city", "Amsterdam"),
+					resource.TestCheckResourceAttr(resourceName, "phone", "020 409 0444"),
+					resource.TestCheckResourceAttr(resourceName, "state_code", "NH"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -73,7 +72,6 @@ func (s *AcceptanceSuite) TestAccAddress_basic() {
 func testAccAddressCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_address" "incentro_address" {
-		  attributes {
 			business     = true
 			company      = "Incentro"
 			line_1       = "Van Nelleweg 1"
@@ -86,7 +84,6 @@ func testAccAddressCreate(testName string) string {
 			  foo: "bar"
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -94,7 +91,6 @@ func testAccAddressCreate(testName string) string {
 func testAccAddressUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_address" "incentro_address" {
-		  attributes {
 			business     = true
 			company      = "Incentro"
 			line_1       = "Moermanskkade 113"
@@ -107,7 +103,6 @@ func testAccAddressUpdate(testName string) string {
 			  bar: "foo"
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
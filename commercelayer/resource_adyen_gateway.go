@@ -8,7 +8,7 @@ import (
 )
 
 func resourceAdyenGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "Configuring a Adyen payment gateway for a market lets you safely process payments through Adyen. " +
 			"The Adyen gateway is compliant with the PSD2 European regulation so that you can implement a payment flow " +
 			"that supports SCA and 3DS2 by using the Adyen's official JS SDK and libraries." +
@@ -21,6 +21,7 @@ func resourceAdyenGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The adyen payment unique identifier",
@@ -32,77 +33,99 @@ func resourceAdyenGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"merchant_account": {
-							Description: "The gateway merchant account.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"api_key": {
-							Description: "The gateway API key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"api_version": {
-							Description: "The checkout API version, supported range is from 66 to 68, default is 68.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"async_api": {
-							Description: "Indicates if the gateway will leverage on the Adyen notification webhooks.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-						"webhook_endpoint_secret": {
-							Description: "The gateway webhook endpoint secret, generated by Adyen customer area.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"public_key": {
-							Description: "The public key linked to your API credential.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"live_url_prefix": {
-							Description: "The prefix of the endpoint used for live transactions.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceAdyenGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceAdyenGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"merchant_account": {
+					Description: "The gateway merchant account.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"api_key": {
+					Description: "The gateway API key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"api_version": {
+					Description: "The checkout API version, supported range is from 66 to 68, default is 68.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"async_api": {
+					Description: "Indicates if the gateway will leverage on the Adyen notification webhooks.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"webhook_endpoint_secret": {
+					Description: "The gateway webhook endpoint secret, generated by Adyen customer area.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"public_key": {
+					Description: "The public key linked to your API credential.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"live_url_prefix": {
+					Description: "The prefix of the endpoint used for live transactions.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -110,9 +133,9 @@ func resourceAdyenGateway() *schema.Resource {
 }
 
 func resourceAdyenGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.AdyenGatewaysApi.GETAdyenGatewaysAdyenGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.AdyenGatewaysApi.GETAdyenGatewaysAdyenGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -125,13 +148,33 @@ func resourceAdyenGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i
 
 	d.SetId(adyenGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceAdyenGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceAdyenGatewayAttributes())
 
 	adyenGatewayCreate := commercelayer.AdyenGatewayCreate{
 		Data: commercelayer.AdyenGatewayCreateData{
@@ -147,7 +190,7 @@ func resourceAdyenGatewayCreateFunc(ctx context.Context, d *schema.ResourceData,
 				LiveUrlPrefix:         attributes["live_url_prefix"].(string),
 				Reference:             stringRef(attributes["reference"]),
 				ReferenceOrigin:       stringRef(attributes["reference_origin"]),
-				Metadata:              keyValueRef(attributes["metadata"]),
+				Metadata:              metadataRef(attributes),
 			},
 		},
 	}
@@ -164,42 +207,76 @@ func resourceAdyenGatewayCreateFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(adyenGateway.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, adyenGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, adyenGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceAdyenGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.AdyenGatewaysApi.DELETEAdyenGatewaysAdyenGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, adyenGatewaysType)
 	return diag.FromErr(err)
 }
 
 func resourceAdyenGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceAdyenGatewayAttributes())
 
 	var adyenGatewayUpdate = commercelayer.AdyenGatewayUpdate{
 		Data: commercelayer.AdyenGatewayUpdateData{
 			Type: adyenGatewaysType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHAdyenGatewaysAdyenGatewayId200ResponseDataAttributes{
-				Name:                  stringRef(attributes["name"]),
-				MerchantAccount:       stringRef(attributes["merchant_account"]),
-				ApiKey:                stringRef(attributes["api_key"]),
-				ApiVersion:            stringRef(attributes["api_version"]),
-				AsyncApi:              boolRef(attributes["async_api"]),
-				WebhookEndpointSecret: stringRef(attributes["webhook_endpoint_secret"]),
-				PublicKey:             stringRef(attributes["public_key"]),
-				LiveUrlPrefix:         stringRef(attributes["live_url_prefix"]),
-				Reference:             stringRef(attributes["reference"]),
-				ReferenceOrigin:       stringRef(attributes["reference_origin"]),
-				Metadata:              keyValueRef(attributes["metadata"]),
+				Name:                  changedAttribute(d, "name", stringRef(attributes["name"])),
+				MerchantAccount:       changedAttribute(d, "merchant_account", stringRef(attributes["merchant_account"])),
+				ApiKey:                changedAttribute(d, "api_key", stringRef(attributes["api_key"])),
+				ApiVersion:            changedAttribute(d, "api_version", stringRef(attributes["api_version"])),
+				AsyncApi:              changedAttribute(d, "async_api", boolRef(attributes["async_api"])),
+				WebhookEndpointSecret: changedAttribute(d, "webhook_endpoint_secret", stringRef(attributes["webhook_endpoint_secret"])),
+				PublicKey:             changedAttribute(d, "public_key", stringRef(attributes["public_key"])),
+				LiveUrlPrefix:         changedAttribute(d, "live_url_prefix", stringRef(attributes["live_url_prefix"])),
+				Reference:             changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:       changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, adyenGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		adyenGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.AdyenGatewaysApi.PATCHAdyenGatewaysAdyenGatewayId(ctx, d.Id()).
 		AdyenGatewayUpdate(adyenGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, adyenGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckAdyenGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_adyen_gateway" {
@@ -36,27 +35,27 @@ func (s *AcceptanceSuite) TestAccAdyenGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckAdyenGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckAdyenGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccAdyenGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", adyenGatewaysType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Adyen Gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.api_version", "68"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.async_api", "true"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.webhook_endpoint_secret", "foobar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Adyen Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "api_version", "68"),
+					resource.TestCheckResourceAttr(resourceName, "async_api", "true"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_endpoint_secret", "foobar"),
 				),
 			},
 			{
 				Config: testAccAdyenGatewayUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Adyen Gateway Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.api_version", "67"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.async_api", "false"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Adyen Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "api_version", "67"),
+					resource.TestCheckResourceAttr(resourceName, "async_api", "false"),
 				),
 			},
 		},
@@ -66,7 +65,6 @@ func (s *AcceptanceSuite) TestAccAdyenGateway_basic() {
 func testAccAdyenGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_adyen_gateway" "incentro_adyen_gateway" {
-           attributes {
 			name                   = "Incentro Adyen Gateway"
 			merchant_account       = "xxxx-yyyy-zzzz"
 			api_key       		   = "xxxx-yyyy-zzzz"
@@ -80,7 +78,6 @@ func testAccAdyenGatewayCreate(testName string) string {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -88,7 +85,6 @@ func testAccAdyenGatewayCreate(testName string) string {
 func testAccAdyenGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_adyen_gateway" "incentro_adyen_gateway" {
-           attributes {
 			name                   = "Incentro Adyen Gateway Changed"
 			merchant_account       = "xxxx-yyyy-zzzz"
 			api_key       		   = "xxxx-yyyy-zzzz"
@@ -101,7 +97,6 @@ func testAccAdyenGatewayUpdate(testName string) string {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -0,0 +1,248 @@
+package commercelayer
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+func resourceAxerveGateway() *schema.Resource {
+	return protectedResource(flattenedResource(&schema.Resource{
+		Description: "Configuring an Axerve payment gateway for a market lets you safely process payments through " +
+			"Axerve. To create an Axerve gateway choose a meaningful name that helps you identify it within your " +
+			"organization and gather the merchant login code and API key provided by Axerve.",
+		ReadContext:   resourceAxerveGatewayReadFunc,
+		CreateContext: resourceAxerveGatewayCreateFunc,
+		UpdateContext: resourceAxerveGatewayUpdateFunc,
+		DeleteContext: resourceAxerveGatewayDeleteFunc,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The axerve payment unique identifier",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "The resource type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes": resourceAxerveGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceAxerveGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"login": {
+					Description: "The merchant login code.",
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+				},
+				"api_key": {
+					Description: "The gateway API key.",
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+			},
+		},
+	}
+}
+
+func resourceAxerveGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	resp, httpResp, err := c.AxerveGatewaysApi.GETAxerveGatewaysAxerveGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	axerveGateway, ok := resp.GetDataOk()
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(axerveGateway.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceAxerveGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceAxerveGatewayAttributes())
+
+	axerveGatewayCreate := commercelayer.AxerveGatewayCreate{
+		Data: commercelayer.AxerveGatewayCreateData{
+			Type: axerveGatewaysType,
+			Attributes: commercelayer.POSTAxerveGateways201ResponseDataAttributes{
+				Name:            attributes["name"].(string),
+				Login:           attributes["login"].(string),
+				ApiKey:          attributes["api_key"].(string),
+				Reference:       stringRef(attributes["reference"]),
+				ReferenceOrigin: stringRef(attributes["reference_origin"]),
+				Metadata:        metadataRef(attributes),
+			},
+		},
+	}
+
+	err := d.Set("type", axerveGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	axerveGateway, _, err := c.AxerveGatewaysApi.POSTAxerveGateways(ctx).
+		AxerveGatewayCreate(axerveGatewayCreate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	d.SetId(axerveGateway.Data.GetId().(string))
+
+	err = waitForCreated(ctx, c, d, axerveGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, axerveGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAxerveGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+	_, err := c.AxerveGatewaysApi.DELETEAxerveGatewaysAxerveGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, axerveGatewaysType)
+	return diag.FromErr(err)
+}
+
+func resourceAxerveGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceAxerveGatewayAttributes())
+
+	var axerveGatewayUpdate = commercelayer.AxerveGatewayUpdate{
+		Data: commercelayer.AxerveGatewayUpdateData{
+			Type: axerveGatewaysType,
+			Id:   d.Id(),
+			Attributes: commercelayer.PATCHAxerveGatewaysAxerveGatewayId200ResponseDataAttributes{
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Login:           changedAttribute(d, "login", stringRef(attributes["login"])),
+				ApiKey:          changedAttribute(d, "api_key", stringRef(attributes["api_key"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+			},
+		},
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, axerveGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		axerveGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	_, _, err := c.AxerveGatewaysApi.PATCHAxerveGatewaysAxerveGatewayId(ctx, d.Id()).
+		AxerveGatewayUpdate(axerveGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, axerveGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
+}
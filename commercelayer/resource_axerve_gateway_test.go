@@ -0,0 +1,89 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccCheckAxerveGatewayDestroy(s *terraform.State) error {
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "commercelayer_axerve_gateway" {
+			_, resp, err := client.AxerveGatewaysApi.
+				GETAxerveGatewaysAxerveGatewayId(context.Background(), rs.Primary.ID).Execute()
+			if resp.StatusCode == 404 {
+				fmt.Printf("commercelayer_axerve_gateway with id %s has been removed\n", rs.Primary.ID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("received response code with status %d", resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+func (s *AcceptanceSuite) TestAccAxerveGateway_basic() {
+	resourceName := "commercelayer_axerve_gateway.incentro_axerve_gateway"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckAxerveGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAxerveGatewayCreate(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", axerveGatewaysType),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Axerve Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccAxerveGatewayUpdate(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Axerve Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAxerveGatewayCreate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_axerve_gateway" "incentro_axerve_gateway" {
+			name    = "Incentro Axerve Gateway"
+			login   = "xxxx-yyyy-zzzz"
+			api_key = "aaaa-bbbb-cccc"
+
+			metadata = {
+			  foo: "bar"
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccAxerveGatewayUpdate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_axerve_gateway" "incentro_axerve_gateway" {
+			name    = "Incentro Axerve Gateway Changed"
+			login   = "xxxx-yyyy-zzzz"
+			api_key = "aaaa-bbbb-cccc"
+
+			metadata = {
+			  bar: "foo"
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
+}
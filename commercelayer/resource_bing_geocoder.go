@@ -8,7 +8,7 @@ import (
 )
 
 func resourceBingGeocoders() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Geocoders lets you convert an address in text form into geographic coordinates " +
 			"(like latitude and longitude). A geocoder can be optionally associated with a market. " +
 			"By connecting a geocoder to a market, all the shipping and billing addresses belonging " +
@@ -20,6 +20,7 @@ func resourceBingGeocoders() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The bing geocoder unique identifier",
@@ -31,45 +32,60 @@ func resourceBingGeocoders() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The geocoder's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. " +
-								"This can be useful for integrating the resource to an external system, like an ERP, a marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"key": {
-							Description: "The Bing Virtualearth key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceBingGeocodersAttributes(),
+		},
+	})
+}
+
+func resourceBingGeocodersAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The geocoder's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. " +
+						"This can be useful for integrating the resource to an external system, like an ERP, a marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"key": {
+					Description: "The Bing Virtualearth key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -77,9 +93,9 @@ func resourceBingGeocoders() *schema.Resource {
 }
 
 func resourceBingGeocodersReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.BingGeocodersApi.GETBingGeocodersBingGeocoderId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.BingGeocodersApi.GETBingGeocodersBingGeocoderId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -92,13 +108,23 @@ func resourceBingGeocodersReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(bingGeocoder.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceBingGeocodersCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceBingGeocodersAttributes())
 
 	bingGeocoderCreate := commercelayer.BingGeocoderCreate{
 		Data: commercelayer.BingGeocoderCreateData{
@@ -107,7 +133,7 @@ func resourceBingGeocodersCreateFunc(ctx context.Context, d *schema.ResourceData
 				Name:            attributes["name"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 				Key:             attributes["key"].(string),
 			},
 		},
@@ -126,34 +152,51 @@ func resourceBingGeocodersCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(bingGeocoders.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, bingGeocodersType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceBingGeocodersDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.BingGeocodersApi.DELETEBingGeocodersBingGeocoderId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, bingGeocodersType)
 	return diag.FromErr(err)
 }
 
 func resourceBingGeocodersUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceBingGeocodersAttributes())
 
 	var bingGeocodersUpdate = commercelayer.BingGeocoderUpdate{
 		Data: commercelayer.BingGeocoderUpdateData{
 			Type: bingGeocodersType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHBingGeocodersBingGeocoderId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-				Key:             stringRef(attributes["key"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+				Key:             changedAttribute(d, "key", stringRef(attributes["key"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, bingGeocodersType)
+		if err != nil {
+			return diagErr(err)
+		}
+		bingGeocodersUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.BingGeocodersApi.PATCHBingGeocodersBingGeocoderId(ctx, d.Id()).BingGeocoderUpdate(bingGeocodersUpdate).Execute()
 
 	return diag.FromErr(err)
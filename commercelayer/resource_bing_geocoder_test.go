@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 	"strings"
 )
 
 func testAccCheckBingGeocoderDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_bing_geocoder" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccBingGeocoder_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckBingGeocoderDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckBingGeocoderDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{testAccBingGeocoderCreate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", bingGeocodersType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Bing Geocoder"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Bing Geocoder"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: strings.Join([]string{testAccBingGeocoderUpdate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Updated Bing Geocoder"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Updated Bing Geocoder"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,27 +61,23 @@ func (s *AcceptanceSuite) TestAccBingGeocoder_basic() {
 func testAccBingGeocoderCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_bing_geocoder" "incentro_bing_geocoder" {
-  			attributes {
     			name                   = "Incentro Bing Geocoder"
     			key               	   = "Bing Virtualearth Key"
 				metadata = {
 			  		foo : "bar"
 		 	 		testName: "{{.testName}}"
 				}
-  			}
 	}`, map[string]any{"testName": testName})
 }
 
 func testAccBingGeocoderUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_bing_geocoder" "incentro_bing_geocoder" {
-  			attributes {
     			name                   = "Incentro Updated Bing Geocoder"
     			key                    = "Bing Virtualearth Key"
 				metadata = {
 			  		bar : "foo"
 		 	 		testName: "{{.testName}}"
 				}
-  			}
 	}`, map[string]any{"testName": testName})
 }
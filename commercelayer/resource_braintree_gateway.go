@@ -8,7 +8,7 @@ import (
 )
 
 func resourceBraintreeGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "Configuring a Braintree payment gateway for a market lets you safely process payments " +
 			"through Braintree. The Braintree gateway is compliant with the PSD2 European regulation " +
 			"so that you can implement a payment flow that supports SCA and 3DS2 by using the " +
@@ -20,6 +20,7 @@ func resourceBraintreeGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The braintree payment unique identifier",
@@ -31,79 +32,101 @@ func resourceBraintreeGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"merchant_account_id": {
-							Description: "The gateway merchant account ID.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"merchant_id": {
-							Description: "The gateway merchant ID.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"public_key": {
-							Description: "The gateway API public key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"private_key": {
-							Description: "The gateway API private key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"descriptor_name": {
-							Description: "The dynamic descriptor name. Must be composed by business name " +
-								"(3, 7 or 12 chars), an asterisk (*) and the product name (18, 14 or 9 chars), " +
-								"for a total length of 22 chars.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"descriptor_phone": {
-							Description: "The dynamic descriptor phone number. Must be 10-14 " +
-								"characters and can only contain numbers, dashes, parentheses and periods.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"descriptor_url": {
-							Description: "The dynamic descriptor URL.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceBraintreeGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceBraintreeGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"merchant_account_id": {
+					Description: "The gateway merchant account ID.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"merchant_id": {
+					Description: "The gateway merchant ID.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"public_key": {
+					Description: "The gateway API public key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"private_key": {
+					Description: "The gateway API private key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"descriptor_name": {
+					Description: "The dynamic descriptor name. Must be composed by business name " +
+						"(3, 7 or 12 chars), an asterisk (*) and the product name (18, 14 or 9 chars), " +
+						"for a total length of 22 chars.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"descriptor_phone": {
+					Description: "The dynamic descriptor phone number. Must be 10-14 " +
+						"characters and can only contain numbers, dashes, parentheses and periods.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"descriptor_url": {
+					Description: "The dynamic descriptor URL.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -111,9 +134,9 @@ func resourceBraintreeGateway() *schema.Resource {
 }
 
 func resourceBraintreeGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.BraintreeGatewaysApi.GETBraintreeGatewaysBraintreeGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.BraintreeGatewaysApi.GETBraintreeGatewaysBraintreeGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -126,13 +149,33 @@ func resourceBraintreeGatewayReadFunc(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(braintreeGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceBraintreeGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceBraintreeGatewayAttributes())
 
 	braintreeGatewayCreate := commercelayer.BraintreeGatewayCreate{
 		Data: commercelayer.BraintreeGatewayCreateData{
@@ -145,7 +188,7 @@ func resourceBraintreeGatewayCreateFunc(ctx context.Context, d *schema.ResourceD
 				PrivateKey:        attributes["private_key"].(string),
 				Reference:         stringRef(attributes["reference"]),
 				ReferenceOrigin:   stringRef(attributes["reference_origin"]),
-				Metadata:          keyValueRef(attributes["metadata"]),
+				Metadata:          metadataRef(attributes),
 			},
 		},
 	}
@@ -162,39 +205,73 @@ func resourceBraintreeGatewayCreateFunc(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(braintreeGateway.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, braintreeGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, braintreeGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceBraintreeGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.BraintreeGatewaysApi.DELETEBraintreeGatewaysBraintreeGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, braintreeGatewaysType)
 	return diag.FromErr(err)
 }
 
 func resourceBraintreeGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceBraintreeGatewayAttributes())
 
 	var braintreeGatewayUpdate = commercelayer.BraintreeGatewayUpdate{
 		Data: commercelayer.BraintreeGatewayUpdateData{
 			Type: braintreeGatewaysType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHBraintreeGatewaysBraintreeGatewayId200ResponseDataAttributes{
-				Name:              stringRef(attributes["name"]),
-				MerchantAccountId: stringRef(attributes["merchant_account_id"]),
-				MerchantId:        stringRef(attributes["merchant_id"]),
-				PublicKey:         stringRef(attributes["public_key"]),
-				PrivateKey:        stringRef(attributes["private_key"]),
-				Reference:         stringRef(attributes["reference"]),
-				ReferenceOrigin:   stringRef(attributes["reference_origin"]),
-				Metadata:          keyValueRef(attributes["metadata"]),
+				Name:              changedAttribute(d, "name", stringRef(attributes["name"])),
+				MerchantAccountId: changedAttribute(d, "merchant_account_id", stringRef(attributes["merchant_account_id"])),
+				MerchantId:        changedAttribute(d, "merchant_id", stringRef(attributes["merchant_id"])),
+				PublicKey:         changedAttribute(d, "public_key", stringRef(attributes["public_key"])),
+				PrivateKey:        changedAttribute(d, "private_key", stringRef(attributes["private_key"])),
+				Reference:         changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:   changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, braintreeGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		braintreeGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.BraintreeGatewaysApi.PATCHBraintreeGatewaysBraintreeGatewayId(ctx, d.Id()).
 		BraintreeGatewayUpdate(braintreeGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, braintreeGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
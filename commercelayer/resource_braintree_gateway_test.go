@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckBraintreeGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_braintree_gateway" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccBraintreeGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckBraintreeGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckBraintreeGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBraintreeGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", braintreeGatewaysType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Braintree Gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Braintree Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccBraintreeGatewayUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Braintree Gateway Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Braintree Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,7 +61,6 @@ func (s *AcceptanceSuite) TestAccBraintreeGateway_basic() {
 func testAccBraintreeGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_braintree_gateway" "incentro_braintree_gateway" {
-           attributes {
 			name                   = "Incentro Braintree Gateway"
 			merchant_account_id    = "xxxx-yyyy-zzzz"
 			merchant_id            = "xxxx-yyyy-zzzz"
@@ -73,7 +71,6 @@ func testAccBraintreeGatewayCreate(testName string) string {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -81,7 +78,6 @@ func testAccBraintreeGatewayCreate(testName string) string {
 func testAccBraintreeGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_braintree_gateway" "incentro_braintree_gateway" {
-           attributes {
 			name                        = "Incentro Braintree Gateway Changed"
 			merchant_account_id         = "xxxx-yyyy-zzzz"
 			merchant_id                 = "xxxx-yyyy-zzzz"
@@ -92,7 +88,6 @@ func testAccBraintreeGatewayUpdate(testName string) string {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceCheckoutComGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "Configuring a CheckoutCom payment gateway for a market lets you safely process payments through CheckoutCom. " +
 			"The CheckoutCom gateway is compliant with the PSD2 European regulation so that you can" +
 			"implement a payment flow that supports SCA and 3DS2 by using the CheckoutCom's official " +
@@ -20,6 +20,7 @@ func resourceCheckoutComGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The checkout.com payment unique identifier",
@@ -31,51 +32,73 @@ func resourceCheckoutComGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"secret_key": {
-							Description: "The gateway secret key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"public_key": {
-							Description: "The gateway public key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceCheckoutComGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceCheckoutComGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"secret_key": {
+					Description: "The gateway secret key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"public_key": {
+					Description: "The gateway public key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -83,9 +106,9 @@ func resourceCheckoutComGateway() *schema.Resource {
 }
 
 func resourceCheckoutComGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.CheckoutComGatewaysApi.GETCheckoutComGatewaysCheckoutComGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.CheckoutComGatewaysApi.GETCheckoutComGatewaysCheckoutComGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -98,13 +121,33 @@ func resourceCheckoutComGatewayReadFunc(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(checkoutComGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceCheckoutComGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceCheckoutComGatewayAttributes())
 
 	checkoutComGatewayCreate := commercelayer.CheckoutComGatewayCreate{
 		Data: commercelayer.CheckoutComGatewayCreateData{
@@ -115,7 +158,7 @@ func resourceCheckoutComGatewayCreateFunc(ctx context.Context, d *schema.Resourc
 				PublicKey:       attributes["public_key"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -132,35 +175,69 @@ func resourceCheckoutComGatewayCreateFunc(ctx context.Context, d *schema.Resourc
 
 	d.SetId(checkoutComGateway.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, checkoutComGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, checkoutComGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceCheckoutComGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.CheckoutComGatewaysApi.DELETECheckoutComGatewaysCheckoutComGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, checkoutComGatewaysType)
 	return diag.FromErr(err)
 }
 
 func resourceCheckoutComGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceCheckoutComGatewayAttributes())
 
 	var checkoutComGatewayUpdate = commercelayer.CheckoutComGatewayUpdate{
 		Data: commercelayer.CheckoutComGatewayUpdateData{
 			Type: checkoutComGatewaysType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHCheckoutComGatewaysCheckoutComGatewayId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, checkoutComGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		checkoutComGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.CheckoutComGatewaysApi.PATCHCheckoutComGatewaysCheckoutComGatewayId(ctx, d.Id()).
 		CheckoutComGatewayUpdate(checkoutComGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, checkoutComGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckCheckoutComGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_checkout_com_gateway" {
@@ -39,22 +38,22 @@ func (s *AcceptanceSuite) TestAccCheckoutComGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckCheckoutComGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckCheckoutComGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckoutComGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", checkoutComGatewaysType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro CheckoutCom Gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro CheckoutCom Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccCheckoutComGatewayUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro CheckoutCom Gateway Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro CheckoutCom Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -64,7 +63,6 @@ func (s *AcceptanceSuite) TestAccCheckoutComGateway_basic() {
 func testAccCheckoutComGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_checkout_com_gateway" "incentro_checkout_com_gateway" {
-           attributes {
 			name                   = "Incentro CheckoutCom Gateway"
 			secret_key 			   = "sk_test_xxxx-yyyy-zzzz"
 			public_key 			   = "pk_test_xxxx-yyyy-zzzz"
@@ -73,7 +71,6 @@ func testAccCheckoutComGatewayCreate(testName string) string {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -81,7 +78,6 @@ func testAccCheckoutComGatewayCreate(testName string) string {
 func testAccCheckoutComGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_checkout_com_gateway" "incentro_checkout_com_gateway" {
-           attributes {
 			name                   = "Incentro CheckoutCom Gateway Changed"
 			secret_key 			   = "sk_test_xxxx-yyyy-zzzz"
 			public_key 			   = "pk_test_xxxx-yyyy-zzzz"
@@ -90,7 +86,6 @@ func testAccCheckoutComGatewayUpdate(testName string) string {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
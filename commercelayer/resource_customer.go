@@ -0,0 +1,293 @@
+package commercelayer
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+func resourceCustomer() *schema.Resource {
+	return flattenedResource(&schema.Resource{
+		Description: "A customer is a shopper identified by their email address. Customers can be associated " +
+			"with a customer group, which gives them access to the private markets of that group, and can " +
+			"save their most-used addresses as customer addresses.",
+		ReadContext:   resourceCustomerReadFunc,
+		CreateContext: resourceCustomerCreateFunc,
+		UpdateContext: resourceCustomerUpdateFunc,
+		DeleteContext: resourceCustomerDeleteFunc,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The customer unique identifier",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "The resource type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes":    resourceCustomerAttributes(),
+			"relationships": resourceCustomerRelationships(),
+		},
+	})
+}
+
+func resourceCustomerAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"email": {
+					Description:      "The customer's email address. The address is stored in lowercase.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: emailValidation,
+					StateFunc:        normalizeEmail,
+				},
+				"password": {
+					Description: "The customer's password. Initiate a customer password reset flow if " +
+						"you need to change it.",
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+				"shopper_reference": {
+					Description: "A reference to uniquely identify the shopper during payment sessions.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+			},
+		},
+	}
+}
+
+func resourceCustomerRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"customer_group_id": {
+					Description: "The associated customer group id.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"tag_ids": {
+					Description: "The associated tag ids.",
+					Type:        schema.TypeList,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceCustomerReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	resp, httpResp, err := c.CustomersApi.GETCustomersCustomerId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	customer, ok := resp.GetDataOk()
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(customer.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceCustomerAttributes())
+	relationships := blockValues(d, "relationships", resourceCustomerRelationships())
+
+	customerCreate := commercelayer.CustomerCreate{
+		Data: commercelayer.CustomerCreateData{
+			Type: customersType,
+			Attributes: commercelayer.POSTCustomers201ResponseDataAttributes{
+				Email:            normalizeEmail(attributes["email"]),
+				Password:         stringRef(attributes["password"]),
+				ShopperReference: stringRef(attributes["shopper_reference"]),
+				Reference:        stringRef(attributes["reference"]),
+				ReferenceOrigin:  stringRef(attributes["reference_origin"]),
+				Metadata:         metadataRef(attributes),
+			},
+			Relationships: &commercelayer.CustomerCreateDataRelationships{},
+		},
+	}
+
+	customerGroupId := stringRef(relationships["customer_group_id"])
+	if customerGroupId != nil {
+		customerCreate.Data.Relationships.CustomerGroup = &commercelayer.CustomerCreateDataRelationshipsCustomerGroup{
+			Data: commercelayer.CustomerDataRelationshipsCustomerGroupData{
+				Type: stringRef(customerGroupType),
+				Id:   customerGroupId,
+			}}
+	}
+
+	err := d.Set("type", customersType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	customer, _, err := c.CustomersApi.POSTCustomers(ctx).CustomerCreate(customerCreate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	d.SetId(customer.Data.GetId().(string))
+
+	err = waitForCreated(ctx, c, d, customersType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	tagIds := stringSliceValueRef(relationships["tag_ids"])
+	if len(tagIds) > 0 {
+		err = patchToManyRelationship(ctx, c, customersType, d.Id(), "tags", tagsType, tagIds)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceCustomerDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+	_, err := c.CustomersApi.DELETECustomersCustomerId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, customersType)
+	return diag.FromErr(err)
+}
+
+func resourceCustomerUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceCustomerAttributes())
+	relationships := blockValues(d, "relationships", resourceCustomerRelationships())
+
+	var customerUpdate = commercelayer.CustomerUpdate{
+		Data: commercelayer.CustomerUpdateData{
+			Type: customersType,
+			Id:   d.Id(),
+			Attributes: commercelayer.PATCHCustomersCustomerId200ResponseDataAttributes{
+				Email:            changedAttribute(d, "email", normalizeEmail(attributes["email"])),
+				ShopperReference: changedAttribute(d, "shopper_reference", stringRef(attributes["shopper_reference"])),
+				Reference:        changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:  changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+			},
+			Relationships: &commercelayer.CustomerCreateDataRelationships{},
+		},
+	}
+
+	// Sending the password on every update would reset it, so it is only sent when it was changed
+	if hasAttributeChange(d, "password") {
+		customerUpdate.Data.Attributes.Password = stringRef(attributes["password"])
+	}
+
+	customerGroupId := stringRef(relationships["customer_group_id"])
+	if customerGroupId != nil && hasRelationshipChange(d, "customer_group_id") {
+		customerUpdate.Data.Relationships.CustomerGroup = &commercelayer.CustomerCreateDataRelationshipsCustomerGroup{
+			Data: commercelayer.CustomerDataRelationshipsCustomerGroupData{
+				Type: stringRef(customerGroupType),
+				Id:   customerGroupId,
+			}}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, customersType)
+		if err != nil {
+			return diagErr(err)
+		}
+		customerUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	_, _, err := c.CustomersApi.PATCHCustomersCustomerId(ctx, d.Id()).CustomerUpdate(customerUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = clearRemovedRelationships(ctx, c, d, customersType, map[string]string{
+		"customer_group_id": "customer_group",
+	})
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if hasRelationshipChange(d, "tag_ids") {
+		err = patchToManyRelationship(ctx, c, customersType, d.Id(), "tags", tagsType,
+			stringSliceValueRef(relationships["tag_ids"]))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package commercelayer
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+func resourceCustomerAddress() *schema.Resource {
+	return flattenedResource(&schema.Resource{
+		Description: "Customer addresses are the addresses saved in a customer's address book. A customer " +
+			"address links an existing address to a customer.",
+		ReadContext:   resourceCustomerAddressReadFunc,
+		CreateContext: resourceCustomerAddressCreateFunc,
+		UpdateContext: resourceCustomerAddressUpdateFunc,
+		DeleteContext: resourceCustomerAddressDeleteFunc,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The customer address unique identifier",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "The resource type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes":    resourceCustomerAddressAttributes(),
+			"relationships": resourceCustomerAddressRelationships(),
+		},
+	})
+}
+
+func resourceCustomerAddressAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"customer_email": {
+					Description: "The email of the customer associated to the address. The address is " +
+						"stored in lowercase.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ForceNew:         true,
+					ValidateDiagFunc: emailValidation,
+					StateFunc:        normalizeEmail,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+			},
+		},
+	}
+}
+
+func resourceCustomerAddressRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"customer_id": {
+					Description: "The associated customer id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"address_id": {
+					Description: "The associated address id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func resourceCustomerAddressReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	resp, httpResp, err := c.CustomerAddressesApi.GETCustomerAddressesCustomerAddressId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	customerAddress, ok := resp.GetDataOk()
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(customerAddress.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerAddressCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceCustomerAddressAttributes())
+	relationships := blockValues(d, "relationships", resourceCustomerAddressRelationships())
+
+	customerAddressCreate := commercelayer.CustomerAddressCreate{
+		Data: commercelayer.CustomerAddressCreateData{
+			Type: customerAddressesType,
+			Attributes: commercelayer.POSTCustomerAddresses201ResponseDataAttributes{
+				CustomerEmail:   normalizeEmail(attributes["customer_email"]),
+				Reference:       stringRef(attributes["reference"]),
+				ReferenceOrigin: stringRef(attributes["reference_origin"]),
+				Metadata:        metadataRef(attributes),
+			},
+			Relationships: &commercelayer.CustomerAddressCreateDataRelationships{
+				Customer: commercelayer.CouponRecipientCreateDataRelationshipsCustomer{
+					Data: commercelayer.CouponRecipientDataRelationshipsCustomerData{
+						Type: stringRef(customersType),
+						Id:   stringRef(relationships["customer_id"]),
+					},
+				},
+				Address: commercelayer.CustomerAddressCreateDataRelationshipsAddress{
+					Data: commercelayer.BingGeocoderDataRelationshipsAddressesData{
+						Type: stringRef(addressType),
+						Id:   stringRef(relationships["address_id"]),
+					},
+				},
+			},
+		},
+	}
+
+	err := d.Set("type", customerAddressesType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	customerAddress, _, err := c.CustomerAddressesApi.POSTCustomerAddresses(ctx).
+		CustomerAddressCreate(customerAddressCreate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	d.SetId(customerAddress.Data.GetId().(string))
+
+	err = waitForCreated(ctx, c, d, customerAddressesType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceCustomerAddressDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+	_, err := c.CustomerAddressesApi.DELETECustomerAddressesCustomerAddressId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, customerAddressesType)
+	return diag.FromErr(err)
+}
+
+func resourceCustomerAddressUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceCustomerAddressAttributes())
+	relationships := blockValues(d, "relationships", resourceCustomerAddressRelationships())
+
+	var customerAddressUpdate = commercelayer.CustomerAddressUpdate{
+		Data: commercelayer.CustomerAddressUpdateData{
+			Type: customerAddressesType,
+			Id:   d.Id(),
+			Attributes: commercelayer.PATCHBillingInfoValidationRulesBillingInfoValidationRuleId200ResponseDataAttributes{
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+			},
+			Relationships: &commercelayer.CustomerAddressUpdateDataRelationships{},
+		},
+	}
+
+	if hasRelationshipChange(d, "customer_id") {
+		customerAddressUpdate.Data.Relationships.Customer = &commercelayer.CouponRecipientCreateDataRelationshipsCustomer{
+			Data: commercelayer.CouponRecipientDataRelationshipsCustomerData{
+				Type: stringRef(customersType),
+				Id:   stringRef(relationships["customer_id"]),
+			},
+		}
+	}
+
+	if hasRelationshipChange(d, "address_id") {
+		customerAddressUpdate.Data.Relationships.Address = &commercelayer.CustomerAddressCreateDataRelationshipsAddress{
+			Data: commercelayer.BingGeocoderDataRelationshipsAddressesData{
+				Type: stringRef(addressType),
+				Id:   stringRef(relationships["address_id"]),
+			},
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, customerAddressesType)
+		if err != nil {
+			return diagErr(err)
+		}
+		customerAddressUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	_, _, err := c.CustomerAddressesApi.PATCHCustomerAddressesCustomerAddressId(ctx, d.Id()).
+		CustomerAddressUpdate(customerAddressUpdate).Execute()
+
+	return diag.FromErr(err)
+}
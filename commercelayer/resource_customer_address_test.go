@@ -0,0 +1,92 @@
+package commercelayer
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"strings"
+)
+
+func testAccCheckCustomerAddressDestroy(s *terraform.State) error {
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "commercelayer_customer_address" {
+			err := testAccWaitForRemoval(client, rs)
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func (s *AcceptanceSuite) TestAccCustomerAddress_basic() {
+	resourceName := "commercelayer_customer_address.incentro_customer_address"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckCustomerAddressDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join([]string{
+					testAccAddressCreate(resourceName),
+					testAccCustomerGroupCreate(resourceName),
+					testAccCustomerCreate(resourceName),
+					testAccCustomerAddressCreate(resourceName),
+				}, "\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", customerAddressesType),
+					resource.TestCheckResourceAttr(resourceName, "customer_email", "b2b-buyer@incentro.com"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+				),
+			},
+			{
+				Config: strings.Join([]string{
+					testAccAddressCreate(resourceName),
+					testAccCustomerGroupCreate(resourceName),
+					testAccCustomerCreate(resourceName),
+					testAccCustomerAddressUpdate(resourceName),
+				}, "\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "reference", "CA-001"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCustomerAddressCreate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_customer_address" "incentro_customer_address" {
+			customer_email = commercelayer_customer.incentro_customer.email
+			metadata = {
+			  foo : "bar"
+			  testName: "{{.testName}}"
+			}
+
+			customer_id = commercelayer_customer.incentro_customer.id
+			address_id  = commercelayer_address.incentro_address.id
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccCustomerAddressUpdate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_customer_address" "incentro_customer_address" {
+			customer_email = commercelayer_customer.incentro_customer.email
+			reference      = "CA-001"
+			metadata = {
+			  bar : "foo"
+			  testName: "{{.testName}}"
+			}
+
+			customer_id = commercelayer_customer.incentro_customer.id
+			address_id  = commercelayer_address.incentro_address.id
+		}
+	`, map[string]any{"testName": testName})
+}
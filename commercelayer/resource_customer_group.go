@@ -8,7 +8,7 @@ import (
 )
 
 func resourceCustomerGroup() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: `A customer group is a resource that can be used to organize customers into groups. 
 		When you associate a customer group to a market, that market becomes private and can be accessed
 		 only by the customers belonging to the group. You can use customer groups to manage B2B customers, 
@@ -20,6 +20,7 @@ func resourceCustomerGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The CustomerGroup unique identifier",
@@ -31,39 +32,56 @@ func resourceCustomerGroup() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The customer group's internal name",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceCustomerGroupAttributes(),
+		},
+	})
+}
+
+func resourceCustomerGroupAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The customer group's internal name",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -71,9 +89,9 @@ func resourceCustomerGroup() *schema.Resource {
 }
 
 func resourceCustomerGroupReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.CustomerGroupsApi.GETCustomerGroupsCustomerGroupId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.CustomerGroupsApi.GETCustomerGroupsCustomerGroupId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -86,13 +104,23 @@ func resourceCustomerGroupReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(customerGroup.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceCustomerGroupCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceCustomerGroupAttributes())
 
 	customerGroupCreate := commercelayer.CustomerGroupCreate{
 		Data: commercelayer.CustomerGroupCreateData{
@@ -101,7 +129,7 @@ func resourceCustomerGroupCreateFunc(ctx context.Context, d *schema.ResourceData
 				Name:            attributes["name"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -118,33 +146,50 @@ func resourceCustomerGroupCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(customerGroup.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, customerGroupType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceCustomerGroupDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.CustomerGroupsApi.DELETECustomerGroupsCustomerGroupId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, customerGroupType)
 	return diag.FromErr(err)
 }
 
 func resourceCustomerGroupUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceCustomerGroupAttributes())
 
 	var customerGroupUpdate = commercelayer.CustomerGroupUpdate{
 		Data: commercelayer.CustomerGroupUpdateData{
 			Type: customerGroupType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHCustomerGroupsCustomerGroupId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"].(string)),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"].(string))),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, customerGroupType)
+		if err != nil {
+			return diagErr(err)
+		}
+		customerGroupUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.CustomerGroupsApi.PATCHCustomerGroupsCustomerGroupId(ctx, d.Id()).CustomerGroupUpdate(customerGroupUpdate).Execute()
 
 	return diag.FromErr(err)
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckCustomerGroupDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_customer_group" {
@@ -36,22 +35,22 @@ func (s *AcceptanceSuite) TestAccCustomerGroup_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckCustomerGroupDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckCustomerGroupDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCustomerGroupCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", customerGroupType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro customer group"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro customer group"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccCustomerGroupUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro updated customer group"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro updated customer group"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -61,13 +60,11 @@ func (s *AcceptanceSuite) TestAccCustomerGroup_basic() {
 func testAccCustomerGroupCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_customer_group" "incentro_customer_group" {
-		  attributes {
 			name = "Incentro customer group"
 			metadata = {
 			  foo : "bar"
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -75,13 +72,11 @@ func testAccCustomerGroupCreate(testName string) string {
 func testAccCustomerGroupUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_customer_group" "incentro_customer_group" {
-		  attributes {
 			name = "Incentro updated customer group"
 			metadata = {
 			  bar : "foo"
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -0,0 +1,87 @@
+package commercelayer
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"strings"
+)
+
+func testAccCheckCustomerDestroy(s *terraform.State) error {
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "commercelayer_customer" {
+			err := testAccWaitForRemoval(client, rs)
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func (s *AcceptanceSuite) TestAccCustomer_basic() {
+	resourceName := "commercelayer_customer.incentro_customer"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckCustomerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join([]string{
+					testAccCustomerGroupCreate(resourceName),
+					testAccCustomerCreate(resourceName),
+				}, "\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", customersType),
+					resource.TestCheckResourceAttr(resourceName, "email", "b2b-buyer@incentro.com"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+				),
+			},
+			{
+				Config: strings.Join([]string{
+					testAccCustomerGroupCreate(resourceName),
+					testAccCustomerUpdate(resourceName),
+				}, "\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "email", "b2b-buyer-changed@incentro.com"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCustomerCreate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_customer" "incentro_customer" {
+			email    = "B2B-Buyer@Incentro.com"
+			password = "super-secret"
+			metadata = {
+			  foo : "bar"
+			  testName: "{{.testName}}"
+			}
+
+			customer_group_id = commercelayer_customer_group.incentro_customer_group.id
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccCustomerUpdate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_customer" "incentro_customer" {
+			email    = "b2b-buyer-changed@incentro.com"
+			password = "super-secret"
+			metadata = {
+			  bar : "foo"
+			  testName: "{{.testName}}"
+			}
+
+			customer_group_id = commercelayer_customer_group.incentro_customer_group.id
+		}
+	`, map[string]any{"testName": testName})
+}
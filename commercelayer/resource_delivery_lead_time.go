@@ -8,7 +8,7 @@ import (
 )
 
 func resourceDeliveryLeadTime() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Delivery lead times provide customers with detailed information about their shipments. " +
 			"This is useful if you ship from many stock locations or offer more shipping method options within a market.",
 		ReadContext:   resourceDeliveryLeadTimesReadFunc,
@@ -18,6 +18,7 @@ func resourceDeliveryLeadTime() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The delivery lead time unique identifier",
@@ -29,67 +30,86 @@ func resourceDeliveryLeadTime() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"min_hours": {
-							Description: "The delivery lead minimum time (in hours) when shipping from the associated stock location with the associated shipping method.",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"max_hours": {
-							Description: "The delivery lead maximum time (in hours) when shipping from the associated stock location with the associated shipping method.",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceDeliveryLeadTimeAttributes(),
+			"relationships": resourceDeliveryLeadTimeRelationships(),
+		},
+	})
+}
+
+func resourceDeliveryLeadTimeAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"min_hours": {
+					Description: "The delivery lead minimum time (in hours) when shipping from the associated stock location with the associated shipping method.",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"max_hours": {
+					Description: "The delivery lead maximum time (in hours) when shipping from the associated stock location with the associated shipping method.",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"stock_location_id": {
-							Description: "The associated stock location id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"shipping_method_id": {
-							Description: "The associated shipping method id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceDeliveryLeadTimeRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"stock_location_id": {
+					Description: "The associated stock location id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"shipping_method_id": {
+					Description: "The associated shipping method id.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -97,9 +117,9 @@ func resourceDeliveryLeadTime() *schema.Resource {
 }
 
 func resourceDeliveryLeadTimesReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.DeliveryLeadTimesApi.GETDeliveryLeadTimesDeliveryLeadTimeId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.DeliveryLeadTimesApi.GETDeliveryLeadTimesDeliveryLeadTimeId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -112,14 +132,24 @@ func resourceDeliveryLeadTimesReadFunc(ctx context.Context, d *schema.ResourceDa
 
 	d.SetId(deliveryLeadTime.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceDeliveryLeadTimesCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceDeliveryLeadTimeAttributes())
+	relationships := blockValues(d, "relationships", resourceDeliveryLeadTimeRelationships())
 
 	deliveryLeadTimeCreate := commercelayer.DeliveryLeadTimeCreate{
 		Data: commercelayer.DeliveryLeadTimeCreateData{
@@ -129,7 +159,7 @@ func resourceDeliveryLeadTimesCreateFunc(ctx context.Context, d *schema.Resource
 				MaxHours:        int32((attributes["max_hours"]).(int)),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 			Relationships: &commercelayer.DeliveryLeadTimeCreateDataRelationships{
 				StockLocation: commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
@@ -159,47 +189,69 @@ func resourceDeliveryLeadTimesCreateFunc(ctx context.Context, d *schema.Resource
 
 	d.SetId(deliveryLeadTimes.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, deliveryLeadTimesType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceDeliveryLeadTimesDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.DeliveryLeadTimesApi.DELETEDeliveryLeadTimesDeliveryLeadTimeId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, deliveryLeadTimesType)
 	return diag.FromErr(err)
 }
 
 func resourceDeliveryLeadTimesUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceDeliveryLeadTimeAttributes())
+	relationships := blockValues(d, "relationships", resourceDeliveryLeadTimeRelationships())
 
 	var deliveryLeadTimeUpdate = commercelayer.DeliveryLeadTimeUpdate{
 		Data: commercelayer.DeliveryLeadTimeUpdateData{
 			Type: deliveryLeadTimesType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHDeliveryLeadTimesDeliveryLeadTimeId200ResponseDataAttributes{
-				MinHours:        intToInt32Ref(attributes["min_hours"]),
-				MaxHours:        intToInt32Ref(attributes["max_hours"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.DeliveryLeadTimeUpdateDataRelationships{
-				StockLocation: &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
-					Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
-						Type: stringRef(stockLocationType),
-						Id:   stringRef(relationships["stock_location_id"]),
-					}},
-				ShippingMethod: &commercelayer.DeliveryLeadTimeCreateDataRelationshipsShippingMethod{
-					Data: commercelayer.DeliveryLeadTimeDataRelationshipsShippingMethodData{
-						Type: stringRef(shippingMethodType),
-						Id:   stringRef(relationships["shipping_method_id"]),
-					}},
+				MinHours:        changedAttribute(d, "min_hours", configuredInt32Ref(d, "min_hours")),
+				MaxHours:        changedAttribute(d, "max_hours", configuredInt32Ref(d, "max_hours")),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.DeliveryLeadTimeUpdateDataRelationships{},
 		},
 	}
 
+	if hasRelationshipChange(d, "stock_location_id") {
+		deliveryLeadTimeUpdate.Data.Relationships.StockLocation = &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
+			Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
+				Type: stringRef(stockLocationType),
+				Id:   stringRef(relationships["stock_location_id"]),
+			}}
+	}
+
+	if hasRelationshipChange(d, "shipping_method_id") {
+		deliveryLeadTimeUpdate.Data.Relationships.ShippingMethod = &commercelayer.DeliveryLeadTimeCreateDataRelationshipsShippingMethod{
+			Data: commercelayer.DeliveryLeadTimeDataRelationshipsShippingMethodData{
+				Type: stringRef(shippingMethodType),
+				Id:   stringRef(relationships["shipping_method_id"]),
+			}}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, deliveryLeadTimesType)
+		if err != nil {
+			return diagErr(err)
+		}
+		deliveryLeadTimeUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.DeliveryLeadTimesApi.PATCHDeliveryLeadTimesDeliveryLeadTimeId(ctx, d.Id()).DeliveryLeadTimeUpdate(deliveryLeadTimeUpdate).Execute()
 
 	return diag.FromErr(err)
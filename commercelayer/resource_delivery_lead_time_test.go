@@ -5,13 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 	"strings"
 )
 
 func testAccCheckDeliveryLeadTimeDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_delivery_lead_time" {
@@ -42,12 +40,7 @@ func testAccCheckDeliveryLeadTimeDestroy(s *terraform.State) error {
 
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type == "commercelayer_inventory_stock_location" {
-				err := retryRemoval(10, func() (*http.Response, error) {
-					_, resp, err := client.InventoryStockLocationsApi.
-						GETInventoryStockLocationsInventoryStockLocationId(context.Background(), rs.Primary.ID).
-						Execute()
-					return resp, err
-				})
+				err := testAccWaitForRemoval(client, rs)
 				if err != nil {
 					return err
 				}
@@ -56,11 +49,7 @@ func testAccCheckDeliveryLeadTimeDestroy(s *terraform.State) error {
 
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type == "commercelayer_shipping_method" {
-				err := retryRemoval(10, func() (*http.Response, error) {
-					_, resp, err := client.ShippingMethodsApi.GETShippingMethodsShippingMethodId(context.Background(), rs.Primary.ID).
-						Execute()
-					return resp, err
-				})
+				err := testAccWaitForRemoval(client, rs)
 				if err != nil {
 					return err
 				}
@@ -78,22 +67,22 @@ func (s *AcceptanceSuite) TestAccDeliveryLeadTime_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckDeliveryLeadTimeDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckDeliveryLeadTimeDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{testAccShippingMethodCreate(resourceName), testAccAddressCreate(resourceName), testAccStockLocationCreate(resourceName), testAccDeliveryLeadTimeCreate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", deliveryLeadTimesType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.min_hours", "10"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "min_hours", "10"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: strings.Join([]string{testAccShippingMethodCreate(resourceName), testAccAddressCreate(resourceName), testAccStockLocationCreate(resourceName), testAccDeliveryLeadTimeUpdate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.min_hours", "20"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "min_hours", "20"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -103,19 +92,15 @@ func (s *AcceptanceSuite) TestAccDeliveryLeadTime_basic() {
 func testAccDeliveryLeadTimeCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_delivery_lead_time" "incentro_delivery_lead_time" {
-		  attributes {
 			min_hours = 10
 			max_hours = 100
 			metadata = {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			stock_location_id = commercelayer_stock_location.incentro_stock_location.id
 			shipping_method_id = commercelayer_shipping_method.incentro_shipping_method.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -123,19 +108,15 @@ func testAccDeliveryLeadTimeCreate(testName string) string {
 func testAccDeliveryLeadTimeUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_delivery_lead_time" "incentro_delivery_lead_time" {
-		  attributes {
 			min_hours = 20
 			max_hours = 200
 			metadata = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			stock_location_id = commercelayer_stock_location.incentro_stock_location.id
 			shipping_method_id = commercelayer_shipping_method.incentro_shipping_method.id
-		  }
 }
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceExternalGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: `Price lists are collections of SKU prices, 
 		defined by currency and market. When a list of SKUs is fetched, 
 		only SKUs with a price defined in the market's price list and at least 
@@ -21,6 +21,7 @@ func resourceExternalGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The external gateway unique identifier",
@@ -32,64 +33,88 @@ func resourceExternalGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
-						"authorize_url": {
-							Description: "The endpoint used by the external gateway to authorize payments.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"capture_url": {
-							Description: "The endpoint used by the external gateway to capture payments.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"void_url": {
-							Description: "The endpoint used by the external gateway to void payments.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"refund_url": {
-							Description: "The endpoint used by the external gateway to refund payments.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"token_url": {
-							Description: "The endpoint used by the external gateway to create a customer payment token.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
+			"attributes": resourceExternalGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceExternalGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+				"authorize_url": {
+					Description: "The endpoint used by the external gateway to authorize payments.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"capture_url": {
+					Description: "The endpoint used by the external gateway to capture payments.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"void_url": {
+					Description: "The endpoint used by the external gateway to void payments.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"refund_url": {
+					Description: "The endpoint used by the external gateway to refund payments.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"token_url": {
+					Description: "The endpoint used by the external gateway to create a customer payment token.",
+					Type:        schema.TypeString,
+					Optional:    true,
 				},
 			},
 		},
@@ -97,9 +122,9 @@ func resourceExternalGateway() *schema.Resource {
 }
 
 func resourceExternalGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.ExternalGatewaysApi.GETExternalGatewaysExternalGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.ExternalGatewaysApi.GETExternalGatewaysExternalGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -112,13 +137,33 @@ func resourceExternalGatewayReadFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(externalGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceExternalGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceExternalGatewayAttributes())
 
 	externalGatewayCreate := commercelayer.ExternalGatewayCreate{
 		Data: commercelayer.ExternalGatewayCreateData{
@@ -127,7 +172,7 @@ func resourceExternalGatewayCreateFunc(ctx context.Context, d *schema.ResourceDa
 				Name:            attributes["name"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 				AuthorizeUrl:    stringRef(attributes["authorize_url"]),
 				CaptureUrl:      stringRef(attributes["capture_url"]),
 				VoidUrl:         stringRef(attributes["void_url"]),
@@ -149,39 +194,73 @@ func resourceExternalGatewayCreateFunc(ctx context.Context, d *schema.ResourceDa
 
 	d.SetId(externalGateway.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, externalGatewayType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, externalGatewayType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceExternalGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.ExternalGatewaysApi.DELETEExternalGatewaysExternalGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, externalGatewayType)
 	return diag.FromErr(err)
 }
 
 func resourceExternalGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceExternalGatewayAttributes())
 
 	var externalGatewayUpdate = commercelayer.ExternalGatewayUpdate{
 		Data: commercelayer.ExternalGatewayUpdateData{
 			Type: externalGatewayType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHExternalGatewaysExternalGatewayId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"].(string)),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-				AuthorizeUrl:    stringRef(attributes["authorize_url"]),
-				CaptureUrl:      stringRef(attributes["capture_url"]),
-				VoidUrl:         stringRef(attributes["void_url"]),
-				TokenUrl:        stringRef(attributes["token_url"]),
-				RefundUrl:       stringRef(attributes["refund_url"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"].(string))),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+				AuthorizeUrl:    changedAttribute(d, "authorize_url", stringRef(attributes["authorize_url"])),
+				CaptureUrl:      changedAttribute(d, "capture_url", stringRef(attributes["capture_url"])),
+				VoidUrl:         changedAttribute(d, "void_url", stringRef(attributes["void_url"])),
+				TokenUrl:        changedAttribute(d, "token_url", stringRef(attributes["token_url"])),
+				RefundUrl:       changedAttribute(d, "refund_url", stringRef(attributes["refund_url"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, externalGatewayType)
+		if err != nil {
+			return diagErr(err)
+		}
+		externalGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.ExternalGatewaysApi.PATCHExternalGatewaysExternalGatewayId(ctx, d.Id()).ExternalGatewayUpdate(externalGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, externalGatewayType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
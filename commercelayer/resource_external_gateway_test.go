@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckExternalGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_external_gateway" {
@@ -36,43 +35,43 @@ func (s *AcceptanceSuite) TestAccExternalGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckExternalGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckExternalGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccExternalGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", externalGatewayType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro_external_gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.authorize_url", "https://example.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.capture_url", "https://example.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.void_url", "https://example.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.refund_url", "https://example.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.token_url", "https://example.com"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro_external_gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "authorize_url", "https://example.com"),
+					resource.TestCheckResourceAttr(resourceName, "capture_url", "https://example.com"),
+					resource.TestCheckResourceAttr(resourceName, "void_url", "https://example.com"),
+					resource.TestCheckResourceAttr(resourceName, "refund_url", "https://example.com"),
+					resource.TestCheckResourceAttr(resourceName, "token_url", "https://example.com"),
 				),
 			},
 			{
 				Config: testAccExternalGatewayUpdateWithUrls(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro_external_gateway_changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.authorize_url", "https://foo.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.capture_url", "https://foo.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.void_url", "https://foo.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.refund_url", "https://foo.com"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.token_url", "https://foo.com"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro_external_gateway_changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "authorize_url", "https://foo.com"),
+					resource.TestCheckResourceAttr(resourceName, "capture_url", "https://foo.com"),
+					resource.TestCheckResourceAttr(resourceName, "void_url", "https://foo.com"),
+					resource.TestCheckResourceAttr(resourceName, "refund_url", "https://foo.com"),
+					resource.TestCheckResourceAttr(resourceName, "token_url", "https://foo.com"),
 				),
 			},
 			{
 				Config: testAccExternalGatewayUpdateWithoutUrls(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					//TODO: check how to do custom value checks
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.authorize_url", ""),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.capture_url", ""),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.void_url", ""),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.refund_url", ""),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.token_url", ""),
+					resource.TestCheckResourceAttr(resourceName, "authorize_url", ""),
+					resource.TestCheckResourceAttr(resourceName, "capture_url", ""),
+					resource.TestCheckResourceAttr(resourceName, "void_url", ""),
+					resource.TestCheckResourceAttr(resourceName, "refund_url", ""),
+					resource.TestCheckResourceAttr(resourceName, "token_url", ""),
 				),
 			},
 		},
@@ -82,7 +81,6 @@ func (s *AcceptanceSuite) TestAccExternalGateway_basic() {
 func testAccExternalGatewayCreate(testName string) string {
 	return hclTemplate(`
 	resource "commercelayer_external_gateway" "incentro_external_gateway" {
-		  attributes {
 			name          = "incentro_external_gateway"
 			authorize_url = "https://example.com"
 			capture_url = "https://example.com"
@@ -93,7 +91,6 @@ func testAccExternalGatewayCreate(testName string) string {
 			  foo : "bar"
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -101,7 +98,6 @@ func testAccExternalGatewayCreate(testName string) string {
 func testAccExternalGatewayUpdateWithUrls(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_external_gateway" "incentro_external_gateway" {
-		  attributes {
 			name          = "incentro_external_gateway_changed"
 			authorize_url = "https://foo.com"
 			capture_url = "https://foo.com"
@@ -112,7 +108,6 @@ func testAccExternalGatewayUpdateWithUrls(testName string) string {
 			  bar : "foo"
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -120,12 +115,10 @@ func testAccExternalGatewayUpdateWithUrls(testName string) string {
 func testAccExternalGatewayUpdateWithoutUrls(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_external_gateway" "incentro_external_gateway" {
-		  attributes {
 			name          = "incentro_external_gateway_changed"
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceExternalTaxCalculator() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Create an external tax calculator to delegate tax calculation" +
 			" logic to the specified external service. Use the order payload to compute " +
 			"your own logic and return the tax rate to be applied to the order.",
@@ -19,6 +19,7 @@ func resourceExternalTaxCalculator() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The external tax calculator unique identifier",
@@ -30,46 +31,61 @@ func resourceExternalTaxCalculator() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The external tax calculator's internal name",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
-						"tax_calculator_url": {
-							Description: "The URL to the service that will compute the taxes.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
+			"attributes": resourceExternalTaxCalculatorAttributes(),
+		},
+	})
+}
+
+func resourceExternalTaxCalculatorAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The external tax calculator's internal name",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+				"tax_calculator_url": {
+					Description: "The URL to the service that will compute the taxes.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -77,9 +93,9 @@ func resourceExternalTaxCalculator() *schema.Resource {
 }
 
 func resourceExternalTaxCalculatorReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.ExternalTaxCalculatorsApi.GETExternalTaxCalculatorsExternalTaxCalculatorId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.ExternalTaxCalculatorsApi.GETExternalTaxCalculatorsExternalTaxCalculatorId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -92,13 +108,23 @@ func resourceExternalTaxCalculatorReadFunc(ctx context.Context, d *schema.Resour
 
 	d.SetId(externalTaxCalculator.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceExternalTaxCalculatorCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceExternalTaxCalculatorAttributes())
 
 	externalTaxCalculatorCreate := commercelayer.ExternalTaxCalculatorCreate{
 		Data: commercelayer.ExternalTaxCalculatorCreateData{
@@ -107,7 +133,7 @@ func resourceExternalTaxCalculatorCreateFunc(ctx context.Context, d *schema.Reso
 				Name:             attributes["name"].(string),
 				Reference:        stringRef(attributes["reference"]),
 				ReferenceOrigin:  stringRef(attributes["reference_origin"]),
-				Metadata:         keyValueRef(attributes["metadata"]),
+				Metadata:         metadataRef(attributes),
 				TaxCalculatorUrl: attributes["tax_calculator_url"].(string),
 			},
 		},
@@ -125,34 +151,51 @@ func resourceExternalTaxCalculatorCreateFunc(ctx context.Context, d *schema.Reso
 
 	d.SetId(externalTaxCalculator.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, externalTaxCalculatorType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceExternalTaxCalculatorDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.ExternalTaxCalculatorsApi.DELETEExternalTaxCalculatorsExternalTaxCalculatorId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, externalTaxCalculatorType)
 	return diag.FromErr(err)
 }
 
 func resourceExternalTaxCalculatorUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceExternalTaxCalculatorAttributes())
 
 	var ExternalTaxCalculatorUpdate = commercelayer.ExternalTaxCalculatorUpdate{
 		Data: commercelayer.ExternalTaxCalculatorUpdateData{
 			Type: externalTaxCalculatorType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHExternalTaxCalculatorsExternalTaxCalculatorId200ResponseDataAttributes{
-				Name:             stringRef(attributes["name"].(string)),
-				Reference:        stringRef(attributes["reference"]),
-				ReferenceOrigin:  stringRef(attributes["reference_origin"]),
-				Metadata:         keyValueRef(attributes["metadata"]),
-				TaxCalculatorUrl: stringRef(attributes["tax_calculator_url"].(string)),
+				Name:             changedAttribute(d, "name", stringRef(attributes["name"].(string))),
+				Reference:        changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:  changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+				TaxCalculatorUrl: changedAttribute(d, "tax_calculator_url", stringRef(attributes["tax_calculator_url"].(string))),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, externalTaxCalculatorType)
+		if err != nil {
+			return diagErr(err)
+		}
+		ExternalTaxCalculatorUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.ExternalTaxCalculatorsApi.PATCHExternalTaxCalculatorsExternalTaxCalculatorId(ctx, d.Id()).ExternalTaxCalculatorUpdate(ExternalTaxCalculatorUpdate).Execute()
 
 	return diag.FromErr(err)
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckExternalTaxCalculatorDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_external_tax_calculator" {
@@ -36,24 +35,24 @@ func (s *AcceptanceSuite) TestAccExternalTaxCalculator_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckExternalTaxCalculatorDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckExternalTaxCalculatorDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccExternalTaxCalculatorCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", externalTaxCalculatorType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro_external_tax_calculator"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.tax_calculator_url", "https://example.com"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro_external_tax_calculator"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "tax_calculator_url", "https://example.com"),
 				),
 			},
 			{
 				Config: testAccExternalTaxCalculatorUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro_external_tax_calculator_changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.tax_calculator_url", "https://foo.com"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro_external_tax_calculator_changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "tax_calculator_url", "https://foo.com"),
 				),
 			},
 		},
@@ -63,14 +62,12 @@ func (s *AcceptanceSuite) TestAccExternalTaxCalculator_basic() {
 func testAccExternalTaxCalculatorCreate(testName string) string {
 	return hclTemplate(`
 	resource "commercelayer_external_tax_calculator" "incentro_external_tax_calculator" {
-	  attributes {
 		name          = "incentro_external_tax_calculator"
 		tax_calculator_url = "https://example.com"
 		metadata = {
 		  foo : "bar"
 		  testName: "{{.testName}}"
 		}
-	  }
 	}
 	`, map[string]any{"testName": testName})
 }
@@ -78,14 +75,12 @@ func testAccExternalTaxCalculatorCreate(testName string) string {
 func testAccExternalTaxCalculatorUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_external_tax_calculator" "incentro_external_tax_calculator" {
-		  attributes {
 			name          = "incentro_external_tax_calculator_changed"
 			tax_calculator_url = "https://foo.com"
 			metadata = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
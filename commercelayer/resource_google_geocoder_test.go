@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 	"strings"
 )
 
 func testAccCheckGoogleGeocoderDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_google_geocoder" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccGoogleGeocoder_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckGoogleGeocoderDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckGoogleGeocoderDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{testAccGoogleGeocoderCreate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", googleGeocodersType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Google Geocoder"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Google Geocoder"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: strings.Join([]string{testAccGoogleGeocoderUpdate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Updated Google Geocoder"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Updated Google Geocoder"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,27 +61,23 @@ func (s *AcceptanceSuite) TestAccGoogleGeocoder_basic() {
 func testAccGoogleGeocoderCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_google_geocoder" "incentro_google_geocoder" {
-  			attributes {
     			name                   = "Incentro Google Geocoder"
     			api_key                = "Google Geocoder API Key"
 				metadata = {
 			  		foo : "bar"
 		 	 		testName: "{{.testName}}"
 				}
-  			}
 	}`, map[string]any{"testName": testName})
 }
 
 func testAccGoogleGeocoderUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_google_geocoder" "incentro_google_geocoder" {
-  			attributes {
     			name                   = "Incentro Updated Google Geocoder"
     			api_key                = "Google Geocoder API Key"
 				metadata = {
 			  		bar : "foo"
 		 	 		testName: "{{.testName}}"
 				}
-  			}
 	}`, map[string]any{"testName": testName})
 }
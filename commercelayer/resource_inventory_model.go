@@ -8,7 +8,7 @@ import (
 )
 
 func resourceInventoryModel() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "An inventory model defines a list of stock locations ordered by priority. The priority and " +
 			"cutoff determine how the availability of SKU's gets calculated within a market.",
 		ReadContext:   resourceInventoryModelReadFunc,
@@ -18,6 +18,7 @@ func resourceInventoryModel() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The inventory model unique identifier",
@@ -29,73 +30,88 @@ func resourceInventoryModel() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The inventory model's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"strategy": {
-							Description: "The inventory model's shipping strategy: one between 'no_split' (default), " +
-								"'split_shipments', 'split_by_line_items', 'ship_from_primary' and 'ship_from_first_available_or_primary'.",
-							Type:             schema.TypeString,
-							Default:          "no_split",
-							Optional:         true,
-							ValidateDiagFunc: inventoryModelStrategyValidation,
-						},
-						"stock_locations_cutoff": {
-							Description: "The maximum number of stock locations used for inventory computation",
-							Type:        schema.TypeInt,
-							Optional:    true,
-							Default:     2,
-						},
-						"stock_reservation_cutoff": {
-							Description: "The duration in seconds of the generated stock reservations.",
-							Type:        schema.TypeInt,
-							Optional:    true,
-							Default:     3600,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"InventoryModeling tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"manual_stock_decrement": {
-							Description: "Indicates if the the stock will be decremented manually after the order approval",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-						"put_stock_transfers_on_hold": {
-							Description: "Indicates if the the stock transfers must be put on hold automatically with the associated shipment.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceInventoryModelAttributes(),
+		},
+	})
+}
+
+func resourceInventoryModelAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The inventory model's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"strategy": {
+					Description: "The inventory model's shipping strategy: one between 'no_split' (default), " +
+						"'split_shipments', 'split_by_line_items', 'ship_from_primary' and 'ship_from_first_available_or_primary'.",
+					Type:             schema.TypeString,
+					Default:          "no_split",
+					Optional:         true,
+					ValidateDiagFunc: inventoryModelStrategyValidation,
+				},
+				"stock_locations_cutoff": {
+					Description: "The maximum number of stock locations used for inventory computation",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     2,
+				},
+				"stock_reservation_cutoff": {
+					Description: "The duration in seconds of the generated stock reservations.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     3600,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"InventoryModeling tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"manual_stock_decrement": {
+					Description: "Indicates if the the stock will be decremented manually after the order approval",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"put_stock_transfers_on_hold": {
+					Description: "Indicates if the the stock transfers must be put on hold automatically with the associated shipment.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -103,9 +119,9 @@ func resourceInventoryModel() *schema.Resource {
 }
 
 func resourceInventoryModelReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.InventoryModelsApi.GETInventoryModelsInventoryModelId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.InventoryModelsApi.GETInventoryModelsInventoryModelId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -118,13 +134,23 @@ func resourceInventoryModelReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(inventoryModel.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceInventoryModelCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceInventoryModelAttributes())
 
 	inventoryModelCreate := commercelayer.InventoryModelCreate{
 		Data: commercelayer.InventoryModelCreateData{
@@ -138,7 +164,7 @@ func resourceInventoryModelCreateFunc(ctx context.Context, d *schema.ResourceDat
 				ManualStockDecrement:    boolRef(attributes["manual_stock_decrement"]),
 				StockReservationCutoff:  intToInt32Ref(attributes["stock_reservation_cutoff"]),
 				PutStockTransfersOnHold: boolRef(attributes["put_stock_transfers_on_hold"]),
-				Metadata:                keyValueRef(attributes["metadata"]),
+				Metadata:                metadataRef(attributes),
 			},
 		},
 	}
@@ -155,38 +181,55 @@ func resourceInventoryModelCreateFunc(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(inventoryModel.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, inventoryModelType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceInventoryModelDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.InventoryModelsApi.DELETEInventoryModelsInventoryModelId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, inventoryModelType)
 	return diag.FromErr(err)
 }
 
 func resourceInventoryModelUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceInventoryModelAttributes())
 
 	var inventoryModelUpdate = commercelayer.InventoryModelUpdate{
 		Data: commercelayer.InventoryModelUpdateData{
 			Type: inventoryModelType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHInventoryModelsInventoryModelId200ResponseDataAttributes{
-				Name:                    stringRef(attributes["name"]),
-				Strategy:                stringRef(attributes["strategy"]),
-				StockLocationsCutoff:    intToInt32Ref(attributes["stock_locations_cutoff"]),
-				Reference:               stringRef(attributes["reference"]),
-				ReferenceOrigin:         stringRef(attributes["reference_origin"]),
-				ManualStockDecrement:    boolRef(attributes["manual_stock_decrement"]),
-				StockReservationCutoff:  intToInt32Ref(attributes["stock_reservation_cutoff"]),
-				PutStockTransfersOnHold: boolRef(attributes["put_stock_transfers_on_hold"]),
-				Metadata:                keyValueRef(attributes["metadata"]),
+				Name:                    changedAttribute(d, "name", stringRef(attributes["name"])),
+				Strategy:                changedAttribute(d, "strategy", stringRef(attributes["strategy"])),
+				StockLocationsCutoff:    changedAttribute(d, "stock_locations_cutoff", configuredInt32Ref(d, "stock_locations_cutoff")),
+				Reference:               changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:         changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+				ManualStockDecrement:    changedAttribute(d, "manual_stock_decrement", boolRef(attributes["manual_stock_decrement"])),
+				StockReservationCutoff:  changedAttribute(d, "stock_reservation_cutoff", configuredInt32Ref(d, "stock_reservation_cutoff")),
+				PutStockTransfersOnHold: changedAttribute(d, "put_stock_transfers_on_hold", boolRef(attributes["put_stock_transfers_on_hold"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, inventoryModelType)
+		if err != nil {
+			return diagErr(err)
+		}
+		inventoryModelUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.InventoryModelsApi.PATCHInventoryModelsInventoryModelId(ctx, d.Id()).
 		InventoryModelUpdate(inventoryModelUpdate).Execute()
 
@@ -1,24 +1,16 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 )
 
 func testAccCheckInventoryModelDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_inventory_model" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.InventoryModelsApi.
-					GETInventoryModelsInventoryModelId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -35,29 +27,29 @@ func (s *AcceptanceSuite) TestAccInventoryModel_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckInventoryModelDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckInventoryModelDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccInventoryModelCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Inventory Model"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.stock_locations_cutoff", "1"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.strategy", "no_split"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.manual_stock_decrement", "true"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.stock_reservation_cutoff", "4000"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.put_stock_transfers_on_hold", "true"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Inventory Model"),
+					resource.TestCheckResourceAttr(resourceName, "stock_locations_cutoff", "1"),
+					resource.TestCheckResourceAttr(resourceName, "strategy", "no_split"),
+					resource.TestCheckResourceAttr(resourceName, "manual_stock_decrement", "true"),
+					resource.TestCheckResourceAttr(resourceName, "stock_reservation_cutoff", "4000"),
+					resource.TestCheckResourceAttr(resourceName, "put_stock_transfers_on_hold", "true"),
 				),
 			},
 			{
 				Config: testAccInventoryModelUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Inventory Model Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.stock_locations_cutoff", "2"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.strategy", "split_shipments"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.manual_stock_decrement", "false"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.stock_reservation_cutoff", "3600"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.put_stock_transfers_on_hold", "false"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Inventory Model Changed"),
+					resource.TestCheckResourceAttr(resourceName, "stock_locations_cutoff", "2"),
+					resource.TestCheckResourceAttr(resourceName, "strategy", "split_shipments"),
+					resource.TestCheckResourceAttr(resourceName, "manual_stock_decrement", "false"),
+					resource.TestCheckResourceAttr(resourceName, "stock_reservation_cutoff", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "put_stock_transfers_on_hold", "false"),
 				),
 			},
 		},
@@ -67,7 +59,6 @@ func (s *AcceptanceSuite) TestAccInventoryModel_basic() {
 func testAccInventoryModelCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_inventory_model" "incentro_inventory_model" {
-		  attributes {
 			name                   = "Incentro Inventory Model"
 			stock_locations_cutoff = 1
 			strategy               = "no_split"
@@ -77,7 +68,6 @@ func testAccInventoryModelCreate(testName string) string {
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -85,7 +75,6 @@ func testAccInventoryModelCreate(testName string) string {
 func testAccInventoryModelUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_inventory_model" "incentro_inventory_model" {
-		  attributes {
 			name                   = "Incentro Inventory Model Changed"
 			stock_locations_cutoff = 2
 			strategy               = "split_shipments"
@@ -95,7 +84,6 @@ func testAccInventoryModelUpdate(testName string) string {
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceInventoryReturnLocation() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Inventory return locations build a hierarchy of stock locations within an inventory " +
 			"model, determining the available options for the returns. In the case a SKU is available in more stock " +
 			"locations, it gets returned to those with the highest priority.",
@@ -19,6 +19,7 @@ func resourceInventoryReturnLocation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The inventory return location unique identifier",
@@ -30,62 +31,81 @@ func resourceInventoryReturnLocation() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"priority": {
-							Description: "The inventory model's internal name.",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"InventoryReturnLocationing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceInventoryReturnLocationAttributes(),
+			"relationships": resourceInventoryReturnLocationRelationships(),
+		},
+	})
+}
+
+func resourceInventoryReturnLocationAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"priority": {
+					Description: "The inventory model's internal name.",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"InventoryReturnLocationing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"stock_location_id": {
-							Description: "The associated stock location id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"inventory_model_id": {
-							Description: "The associated inventory model id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceInventoryReturnLocationRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"stock_location_id": {
+					Description: "The associated stock location id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"inventory_model_id": {
+					Description: "The associated inventory model id.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -93,9 +113,9 @@ func resourceInventoryReturnLocation() *schema.Resource {
 }
 
 func resourceInventoryReturnLocationReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.InventoryReturnLocationsApi.GETInventoryReturnLocationsInventoryReturnLocationId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.InventoryReturnLocationsApi.GETInventoryReturnLocationsInventoryReturnLocationId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -108,14 +128,24 @@ func resourceInventoryReturnLocationReadFunc(ctx context.Context, d *schema.Reso
 
 	d.SetId(inventoryModel.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceInventoryReturnLocationCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceInventoryReturnLocationAttributes())
+	relationships := blockValues(d, "relationships", resourceInventoryReturnLocationRelationships())
 
 	inventoryModelCreate := commercelayer.InventoryReturnLocationCreate{
 		Data: commercelayer.InventoryReturnLocationCreateData{
@@ -124,7 +154,7 @@ func resourceInventoryReturnLocationCreateFunc(ctx context.Context, d *schema.Re
 				Priority:        int32(attributes["priority"].(int)),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 			Relationships: &commercelayer.InventoryReturnLocationCreateDataRelationships{
 				StockLocation: commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
@@ -155,48 +185,70 @@ func resourceInventoryReturnLocationCreateFunc(ctx context.Context, d *schema.Re
 
 	d.SetId(inventoryModel.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, inventoryReturnLocationsType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceInventoryReturnLocationDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.InventoryReturnLocationsApi.DELETEInventoryReturnLocationsInventoryReturnLocationId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, inventoryReturnLocationsType)
 	return diag.FromErr(err)
 }
 
 func resourceInventoryReturnLocationUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceInventoryReturnLocationAttributes())
+	relationships := blockValues(d, "relationships", resourceInventoryReturnLocationRelationships())
 
 	var inventoryModelUpdate = commercelayer.InventoryReturnLocationUpdate{
 		Data: commercelayer.InventoryReturnLocationUpdateData{
 			Type: inventoryReturnLocationsType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHInventoryReturnLocationsInventoryReturnLocationId200ResponseDataAttributes{
-				Priority:        intToInt32Ref(attributes["priority"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.InventoryReturnLocationUpdateDataRelationships{
-				StockLocation: &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
-					Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
-						Type: stringRef(stockLocationType),
-						Id:   stringRef(relationships["stock_location_id"]),
-					},
-				},
-				InventoryModel: &commercelayer.InventoryReturnLocationCreateDataRelationshipsInventoryModel{
-					Data: commercelayer.InventoryReturnLocationDataRelationshipsInventoryModelData{
-						Type: stringRef(inventoryModelType),
-						Id:   stringRef(relationships["inventory_model_id"]),
-					},
-				},
+				Priority:        changedAttribute(d, "priority", configuredInt32Ref(d, "priority")),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.InventoryReturnLocationUpdateDataRelationships{},
 		},
 	}
 
+	if hasRelationshipChange(d, "stock_location_id") {
+		inventoryModelUpdate.Data.Relationships.StockLocation = &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
+			Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
+				Type: stringRef(stockLocationType),
+				Id:   stringRef(relationships["stock_location_id"]),
+			},
+		}
+	}
+
+	if hasRelationshipChange(d, "inventory_model_id") {
+		inventoryModelUpdate.Data.Relationships.InventoryModel = &commercelayer.InventoryReturnLocationCreateDataRelationshipsInventoryModel{
+			Data: commercelayer.InventoryReturnLocationDataRelationshipsInventoryModelData{
+				Type: stringRef(inventoryModelType),
+				Id:   stringRef(relationships["inventory_model_id"]),
+			},
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, inventoryReturnLocationsType)
+		if err != nil {
+			return diagErr(err)
+		}
+		inventoryModelUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.InventoryReturnLocationsApi.PATCHInventoryReturnLocationsInventoryReturnLocationId(ctx, d.Id()).
 		InventoryReturnLocationUpdate(inventoryModelUpdate).Execute()
 
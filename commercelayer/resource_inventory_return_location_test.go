@@ -1,25 +1,17 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 	"strings"
 )
 
 func testAccCheckInventoryReturnLocationDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_inventory_return_location" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.InventoryReturnLocationsApi.
-					GETInventoryReturnLocationsInventoryReturnLocationId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -36,8 +28,8 @@ func (s *AcceptanceSuite) TestAccInventoryReturnLocation_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckInventoryReturnLocationDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckInventoryReturnLocationDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{
@@ -47,7 +39,7 @@ func (s *AcceptanceSuite) TestAccInventoryReturnLocation_basic() {
 					testAccInventoryReturnLocationCreate(resourceName),
 				}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.priority", "1"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "1"),
 				),
 			},
 			{
@@ -58,7 +50,7 @@ func (s *AcceptanceSuite) TestAccInventoryReturnLocation_basic() {
 					testAccInventoryReturnLocationUpdate(resourceName),
 				}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.priority", "2"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "2"),
 				),
 			},
 		},
@@ -68,17 +60,13 @@ func (s *AcceptanceSuite) TestAccInventoryReturnLocation_basic() {
 func testAccInventoryReturnLocationCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_inventory_return_location" "incentro_inventory_return_location" {
-		  attributes {
 			priority = 1
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			inventory_model_id = commercelayer_inventory_model.incentro_inventory_model.id
 			stock_location_id  = commercelayer_stock_location.incentro_stock_location.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -86,17 +74,13 @@ func testAccInventoryReturnLocationCreate(testName string) string {
 func testAccInventoryReturnLocationUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_inventory_return_location" "incentro_inventory_return_location" {
-		  attributes {
 			priority = 2
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			inventory_model_id = commercelayer_inventory_model.incentro_inventory_model.id
 			stock_location_id  = commercelayer_stock_location.incentro_stock_location.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceInventoryStockLocation() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Inventory stock locations build a hierarchy of stock locations within an inventory " +
 			"model, determining the availability of SKU's that are being purchased. In the case a SKU is available " +
 			"in more stock locations, it gets shipped from those with the highest priority.",
@@ -19,6 +19,7 @@ func resourceInventoryStockLocation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The inventory return location unique identifier",
@@ -30,70 +31,89 @@ func resourceInventoryStockLocation() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"priority": {
-							Description: "The stock location priority within the associated inventory model.",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"on_hold": {
-							Description: "Indicates if the shipment should be put on hold if fulfilled from the " +
-								"associated stock location. This is useful to manage use cases like back-orders, " +
-								"pre-orders or personalized orders that need to be customized before being fulfilled.",
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"InventoryStockLocationing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceInventoryStockLocationAttributes(),
+			"relationships": resourceInventoryStockLocationRelationships(),
+		},
+	})
+}
+
+func resourceInventoryStockLocationAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"priority": {
+					Description: "The stock location priority within the associated inventory model.",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"on_hold": {
+					Description: "Indicates if the shipment should be put on hold if fulfilled from the " +
+						"associated stock location. This is useful to manage use cases like back-orders, " +
+						"pre-orders or personalized orders that need to be customized before being fulfilled.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"InventoryStockLocationing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"stock_location_id": {
-							Description: "The associated stock location id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"inventory_model_id": {
-							Description: "The associated inventory model id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceInventoryStockLocationRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"stock_location_id": {
+					Description: "The associated stock location id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"inventory_model_id": {
+					Description: "The associated inventory model id.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -101,9 +121,9 @@ func resourceInventoryStockLocation() *schema.Resource {
 }
 
 func resourceInventoryStockLocationReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.InventoryStockLocationsApi.GETInventoryStockLocationsInventoryStockLocationId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.InventoryStockLocationsApi.GETInventoryStockLocationsInventoryStockLocationId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -116,14 +136,24 @@ func resourceInventoryStockLocationReadFunc(ctx context.Context, d *schema.Resou
 
 	d.SetId(inventoryModel.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceInventoryStockLocationCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceInventoryStockLocationAttributes())
+	relationships := blockValues(d, "relationships", resourceInventoryStockLocationRelationships())
 
 	inventoryModelCreate := commercelayer.InventoryStockLocationCreate{
 		Data: commercelayer.InventoryStockLocationCreateData{
@@ -133,7 +163,7 @@ func resourceInventoryStockLocationCreateFunc(ctx context.Context, d *schema.Res
 				OnHold:          boolRef(attributes["on_hold"]),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 			Relationships: &commercelayer.InventoryReturnLocationCreateDataRelationships{
 				StockLocation: commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
@@ -164,49 +194,71 @@ func resourceInventoryStockLocationCreateFunc(ctx context.Context, d *schema.Res
 
 	d.SetId(inventoryModel.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, inventoryStockLocationsType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceInventoryStockLocationDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.InventoryStockLocationsApi.DELETEInventoryStockLocationsInventoryStockLocationId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, inventoryStockLocationsType)
 	return diag.FromErr(err)
 }
 
 func resourceInventoryStockLocationUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceInventoryStockLocationAttributes())
+	relationships := blockValues(d, "relationships", resourceInventoryStockLocationRelationships())
 
 	var inventoryModelUpdate = commercelayer.InventoryStockLocationUpdate{
 		Data: commercelayer.InventoryStockLocationUpdateData{
 			Type: inventoryStockLocationsType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHInventoryStockLocationsInventoryStockLocationId200ResponseDataAttributes{
-				Priority:        intToInt32Ref(attributes["priority"]),
-				OnHold:          boolRef(attributes["on_hold"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.InventoryReturnLocationUpdateDataRelationships{
-				StockLocation: &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
-					Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
-						Type: stringRef(stockLocationType),
-						Id:   stringRef(relationships["stock_location_id"]),
-					},
-				},
-				InventoryModel: &commercelayer.InventoryReturnLocationCreateDataRelationshipsInventoryModel{
-					Data: commercelayer.InventoryReturnLocationDataRelationshipsInventoryModelData{
-						Type: stringRef(inventoryModelType),
-						Id:   stringRef(relationships["inventory_model_id"]),
-					},
-				},
+				Priority:        changedAttribute(d, "priority", configuredInt32Ref(d, "priority")),
+				OnHold:          changedAttribute(d, "on_hold", boolRef(attributes["on_hold"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.InventoryReturnLocationUpdateDataRelationships{},
 		},
 	}
 
+	if hasRelationshipChange(d, "stock_location_id") {
+		inventoryModelUpdate.Data.Relationships.StockLocation = &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
+			Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
+				Type: stringRef(stockLocationType),
+				Id:   stringRef(relationships["stock_location_id"]),
+			},
+		}
+	}
+
+	if hasRelationshipChange(d, "inventory_model_id") {
+		inventoryModelUpdate.Data.Relationships.InventoryModel = &commercelayer.InventoryReturnLocationCreateDataRelationshipsInventoryModel{
+			Data: commercelayer.InventoryReturnLocationDataRelationshipsInventoryModelData{
+				Type: stringRef(inventoryModelType),
+				Id:   stringRef(relationships["inventory_model_id"]),
+			},
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, inventoryStockLocationsType)
+		if err != nil {
+			return diagErr(err)
+		}
+		inventoryModelUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.InventoryStockLocationsApi.PATCHInventoryStockLocationsInventoryStockLocationId(ctx, d.Id()).
 		InventoryStockLocationUpdate(inventoryModelUpdate).Execute()
 
@@ -1,25 +1,17 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 	"strings"
 )
 
 func testAccCheckInventoryStockLocationDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_inventory_stock_location" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.InventoryStockLocationsApi.
-					GETInventoryStockLocationsInventoryStockLocationId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -36,8 +28,8 @@ func (s *AcceptanceSuite) TestAccInventoryStockLocation_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckInventoryStockLocationDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckInventoryStockLocationDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{
@@ -47,8 +39,8 @@ func (s *AcceptanceSuite) TestAccInventoryStockLocation_basic() {
 					testAccInventoryStockLocationCreate(resourceName),
 				}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.priority", "1"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.on_hold", "true"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "1"),
+					resource.TestCheckResourceAttr(resourceName, "on_hold", "true"),
 				),
 			},
 			{
@@ -59,8 +51,8 @@ func (s *AcceptanceSuite) TestAccInventoryStockLocation_basic() {
 					testAccInventoryStockLocationUpdate(resourceName),
 				}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.priority", "2"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.on_hold", "false"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "2"),
+					resource.TestCheckResourceAttr(resourceName, "on_hold", "false"),
 				),
 			},
 		},
@@ -70,18 +62,14 @@ func (s *AcceptanceSuite) TestAccInventoryStockLocation_basic() {
 func testAccInventoryStockLocationCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_inventory_stock_location" "incentro_inventory_stock_location" {
-		  attributes {
 			priority = 1
 			on_hold  = true
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			inventory_model_id = commercelayer_inventory_model.incentro_inventory_model.id
 			stock_location_id  = commercelayer_stock_location.incentro_stock_location.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -89,18 +77,14 @@ func testAccInventoryStockLocationCreate(testName string) string {
 func testAccInventoryStockLocationUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_inventory_stock_location" "incentro_inventory_stock_location" {
-		  attributes {
 			priority = 2
 			on_hold  = false
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			inventory_model_id = commercelayer_inventory_model.incentro_inventory_model.id
 			stock_location_id  = commercelayer_stock_location.incentro_stock_location.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
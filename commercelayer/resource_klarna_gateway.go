@@ -8,7 +8,7 @@ import (
 )
 
 func resourceKlarnaGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "Configuring a Klarna payment gateway for a market lets you safely process payments through Klarna. " +
 			"The Klarna gateway is compliant with the PSD2 European regulation so that you can" +
 			"implement a payment flow that supports SCA and 3DS2 by using the Klarna's official " +
@@ -20,6 +20,7 @@ func resourceKlarnaGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The klarna payment unique identifier",
@@ -31,56 +32,78 @@ func resourceKlarnaGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"country_code": {
-							Description: "The gateway country code one of EU, US, or OC.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"api_key": {
-							Description: "The public key linked to your API credential.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"api_secret": {
-							Description: "The gateway API key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceKlarnaGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceKlarnaGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"country_code": {
+					Description: "The gateway country code one of EU, US, or OC.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"api_key": {
+					Description: "The public key linked to your API credential.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"api_secret": {
+					Description: "The gateway API key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -88,9 +111,9 @@ func resourceKlarnaGateway() *schema.Resource {
 }
 
 func resourceKlarnaGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.KlarnaGatewaysApi.GETKlarnaGatewaysKlarnaGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.KlarnaGatewaysApi.GETKlarnaGatewaysKlarnaGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -103,13 +126,33 @@ func resourceKlarnaGatewayReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(klarnaGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceKlarnaGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceKlarnaGatewayAttributes())
 
 	klarnaGatewayCreate := commercelayer.KlarnaGatewayCreate{
 		Data: commercelayer.KlarnaGatewayCreateData{
@@ -121,7 +164,7 @@ func resourceKlarnaGatewayCreateFunc(ctx context.Context, d *schema.ResourceData
 				ApiSecret:       attributes["api_secret"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -138,38 +181,72 @@ func resourceKlarnaGatewayCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(klarnaGateway.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, klarnaGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, klarnaGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceKlarnaGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.KlarnaGatewaysApi.DELETEKlarnaGatewaysKlarnaGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, klarnaGatewaysType)
 	return diag.FromErr(err)
 }
 
 func resourceKlarnaGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceKlarnaGatewayAttributes())
 
 	var klarnaGatewayUpdate = commercelayer.KlarnaGatewayUpdate{
 		Data: commercelayer.KlarnaGatewayUpdateData{
 			Type: klarnaGatewaysType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHKlarnaGatewaysKlarnaGatewayId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				CountryCode:     stringRef(attributes["country_code"]),
-				ApiKey:          stringRef(attributes["api_key"]),
-				ApiSecret:       stringRef(attributes["api_secret"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				CountryCode:     changedAttribute(d, "country_code", stringRef(attributes["country_code"])),
+				ApiKey:          changedAttribute(d, "api_key", stringRef(attributes["api_key"])),
+				ApiSecret:       changedAttribute(d, "api_secret", stringRef(attributes["api_secret"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, klarnaGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		klarnaGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.KlarnaGatewaysApi.PATCHKlarnaGatewaysKlarnaGatewayId(ctx, d.Id()).
 		KlarnaGatewayUpdate(klarnaGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, klarnaGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckKlarnaGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_klarna_gateway" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccKlarnaGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckKlarnaGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckKlarnaGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccKlarnaGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", klarnaGatewaysType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Klarna Gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Klarna Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccKlarnaGatewayUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Klarna Gateway Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Klarna Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,7 +61,6 @@ func (s *AcceptanceSuite) TestAccKlarnaGateway_basic() {
 func testAccKlarnaGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_klarna_gateway" "incentro_klarna_gateway" {
-           attributes {
 			name                   = "Incentro Klarna Gateway"
 			country_code              = "EU"
 			api_key              = "xxxx-yyyy-zzzz"
@@ -72,7 +70,6 @@ func testAccKlarnaGatewayCreate(testName string) string {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -80,7 +77,6 @@ func testAccKlarnaGatewayCreate(testName string) string {
 func testAccKlarnaGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_klarna_gateway" "incentro_klarna_gateway" {
-           attributes {
 			name                   = "Incentro Klarna Gateway Changed"
 			country_code              = "EU"
 			api_key              = "xxxx-yyyy-zzzz"
@@ -90,7 +86,6 @@ func testAccKlarnaGatewayUpdate(testName string) string {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
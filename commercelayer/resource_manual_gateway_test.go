@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckManualGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_manual_gateway" {
@@ -30,6 +29,8 @@ func testAccCheckManualGatewayDestroy(s *terraform.State) error {
 	return nil
 }
 
+// TestAccManualGateway_basic keeps using the deprecated attributes block, to cover configurations written for
+// schema version 0, and checks that moving its fields to the top level plans no changes.
 func (s *AcceptanceSuite) TestAccManualGateway_basic() {
 	resourceName := "commercelayer_manual_gateway.incentro_manual_gateway"
 
@@ -37,8 +38,8 @@ func (s *AcceptanceSuite) TestAccManualGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckManualGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckManualGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccManualGatewayCreate(resourceName),
@@ -46,6 +47,7 @@ func (s *AcceptanceSuite) TestAccManualGateway_basic() {
 					resource.TestCheckResourceAttr(resourceName, "type", manualGatewaysType),
 					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Manual Gateway"),
 					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "attributes.0.enabled", "true"),
 				),
 			},
 			{
@@ -55,6 +57,10 @@ func (s *AcceptanceSuite) TestAccManualGateway_basic() {
 					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
 				),
 			},
+			{
+				Config:   testAccManualGatewayUpdateTopLevel(resourceName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -62,27 +68,39 @@ func (s *AcceptanceSuite) TestAccManualGateway_basic() {
 func testAccManualGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_manual_gateway" "incentro_manual_gateway" {
-           attributes {
-			name                   = "Incentro Manual Gateway"
+		  attributes {
+			name     = "Incentro Manual Gateway"
 			metadata = {
-				foo: "bar"
-				testName: "{{.testName}}"
-    		}
-  		}
-	}
-`, map[string]any{"testName": testName})
+			  foo: "bar"
+			  testName: "{{.testName}}"
+			}
+		  }
+		}
+	`, map[string]any{"testName": testName})
 }
 
 func testAccManualGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_manual_gateway" "incentro_manual_gateway" {
-           attributes {
-			name                   = "Incentro Manual Gateway Changed"
+		  attributes {
+			name     = "Incentro Manual Gateway Changed"
 			metadata = {
-				bar: "foo"
-				testName: "{{.testName}}"
-    		}
-  		}
-	}
-`, map[string]any{"testName": testName})
+			  bar: "foo"
+			  testName: "{{.testName}}"
+			}
+		  }
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccManualGatewayUpdateTopLevel(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_manual_gateway" "incentro_manual_gateway" {
+			name     = "Incentro Manual Gateway Changed"
+			metadata = {
+			  bar: "foo"
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceManualTaxCalculator() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Configure the manual tax calculator by creating one or more associated tax rules. " +
 			"The rules will apply the related tax rate to the matching orders.",
 		ReadContext:   resourceManualTaxCalculatorReadFunc,
@@ -18,6 +18,7 @@ func resourceManualTaxCalculator() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The manual tax calculator unique identifier",
@@ -29,41 +30,56 @@ func resourceManualTaxCalculator() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The tax calculator's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceManualTaxCalculatorAttributes(),
+		},
+	})
+}
+
+func resourceManualTaxCalculatorAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The tax calculator's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -71,9 +87,9 @@ func resourceManualTaxCalculator() *schema.Resource {
 }
 
 func resourceManualTaxCalculatorReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.ManualTaxCalculatorsApi.GETManualTaxCalculatorsManualTaxCalculatorId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.ManualTaxCalculatorsApi.GETManualTaxCalculatorsManualTaxCalculatorId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -86,13 +102,23 @@ func resourceManualTaxCalculatorReadFunc(ctx context.Context, d *schema.Resource
 
 	d.SetId(manualTaxCalculator.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceManualTaxCalculatorCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceManualTaxCalculatorAttributes())
 
 	manualTaxCalculatorCreate := commercelayer.ManualTaxCalculatorCreate{
 		Data: commercelayer.ManualTaxCalculatorCreateData{
@@ -101,7 +127,7 @@ func resourceManualTaxCalculatorCreateFunc(ctx context.Context, d *schema.Resour
 				Name:            attributes["name"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -118,33 +144,50 @@ func resourceManualTaxCalculatorCreateFunc(ctx context.Context, d *schema.Resour
 
 	d.SetId(manualTaxCalculator.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, manualTaxCalculatorsType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceManualTaxCalculatorDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.ManualTaxCalculatorsApi.DELETEManualTaxCalculatorsManualTaxCalculatorId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, manualTaxCalculatorsType)
 	return diag.FromErr(err)
 }
 
 func resourceManualTaxCalculatorUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceManualTaxCalculatorAttributes())
 
 	var manualTaxCalculatorUpdate = commercelayer.ManualTaxCalculatorUpdate{
 		Data: commercelayer.ManualTaxCalculatorUpdateData{
 			Type: manualTaxCalculatorsType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHManualTaxCalculatorsManualTaxCalculatorId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, manualTaxCalculatorsType)
+		if err != nil {
+			return diagErr(err)
+		}
+		manualTaxCalculatorUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.ManualTaxCalculatorsApi.PATCHManualTaxCalculatorsManualTaxCalculatorId(ctx, d.Id()).
 		ManualTaxCalculatorUpdate(manualTaxCalculatorUpdate).Execute()
 
@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckManualTaxCalculatorDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_manual_tax_calculator" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccManualTaxCalculator_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckManualTaxCalculatorDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckManualTaxCalculatorDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccManualTaxCalculatorCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", manualTaxCalculatorsType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Manual Tax Calculator"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Manual Tax Calculator"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccManualTaxCalculatorUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Manual Tax Calculator Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Manual Tax Calculator Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,13 +61,11 @@ func (s *AcceptanceSuite) TestAccManualTaxCalculator_basic() {
 func testAccManualTaxCalculatorCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_manual_tax_calculator" "incentro_manual_tax_calculator" {
-           attributes {
 			name                   = "Incentro Manual Tax Calculator"
 			metadata = {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -76,13 +73,11 @@ func testAccManualTaxCalculatorCreate(testName string) string {
 func testAccManualTaxCalculatorUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_manual_tax_calculator" "incentro_manual_tax_calculator" {
-           attributes {
 			name                   = "Incentro Manual Tax Calculator Changed"
 			metadata = {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceMarket() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "A market is made of a merchant, an inventory model, and a price list (plus an optional " +
 			"customer group, geocoder, and tax calculator)",
 		ReadContext:   resourceMarketReadFunc,
@@ -18,6 +18,7 @@ func resourceMarket() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The market unique identifier",
@@ -29,107 +30,161 @@ func resourceMarket() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The Market's internal name",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"code": {
-							Description: "A string that you can use to identify the market (must be unique within the environment).",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"facebook_pixel_id": {
-							Description: "The Facebook Pixed ID",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"checkout_url": {
-							Description: "The checkout URL for this market",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"external_prices_url": {
-							Description: "The URL used to fetch prices from an external source",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"external_order_validation_url": {
-							Description: "The URL used to validate orders by an external source.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"shipping_cost_cutoff": {
-							Description: "When specified indicates the maximum number of shipping line items with cost that will be added to an order.",
-							Type:        schema.TypeInt,
-							Optional:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"private": {
+				Description: "Indicates if the market is private, which is the case when it belongs to a customer group.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"shared_secret": {
+				Description: "The shared secret used to sign the external requests payload.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"adopt_existing": adoptExistingSchema("market", "code"),
+			"attributes":     resourceMarketAttributes(),
+			"relationships":  resourceMarketRelationships(),
+		},
+	}))
+}
+
+func resourceMarketAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The Market's internal name",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"code": {
+					Description: "A string that you can use to identify the market (must be unique within the environment).",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"facebook_pixel_id": {
+					Description: "The Facebook Pixed ID",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"checkout_url": {
+					Description: "The checkout URL for this market",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"external_prices_url": {
+					Description: "The URL used to fetch prices from an external source",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"external_order_validation_url": {
+					Description: "The URL used to validate orders by an external source.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"shipping_cost_cutoff": {
+					Description: "When specified indicates the maximum number of shipping line items with cost that will be added to an order.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the market is enabled, disabled markets can't " +
+						"be used to place orders.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"merchant_id": {
-							Description: "The associated merchant id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"price_list_id": {
-							Description: "The associated price list id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"inventory_model_id": {
-							Description: "The associated inventory model id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"customer_group_id": {
-							Description: "The associated customer group id.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"tax_calculator_id": {
-							Description: "The associated tax calculator id.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceMarketRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"merchant_id": {
+					Description: "The associated merchant id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"price_list_id": {
+					Description: "The associated price list id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"inventory_model_id": {
+					Description: "The associated inventory model id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"customer_group_id": {
+					Description: "The associated customer group id.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"tax_calculator_id": {
+					Description: "The associated tax calculator id.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"subscription_model_id": {
+					Description: "The associated subscription model id.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"geocoder_id": {
+					Description: "The associated geocoder id, used to geocode the addresses of the market.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"base_price_list_id": {
+					Description: "The associated base price list id. The base price list is used when none " +
+						"of the price list schedulers of the market is active.",
+					Type:     schema.TypeString,
+					Optional: true,
 				},
 			},
 		},
@@ -137,9 +192,9 @@ func resourceMarket() *schema.Resource {
 }
 
 func resourceMarketReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.MarketsApi.GETMarketsMarketId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.MarketsApi.GETMarketsMarketId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -152,14 +207,39 @@ func resourceMarketReadFunc(ctx context.Context, d *schema.ResourceData, i inter
 
 	d.SetId(Market.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	attributes := Market.GetAttributes()
+	err = setEnabled(d, attributes.GetDisabledAt())
+	if err != nil {
+		return diagErr(err)
+	}
+
+	for key, value := range map[string]interface{}{
+		"private":       attributes.GetPrivate(),
+		"shared_secret": attributes.GetSharedSecret(),
+	} {
+		if err := d.Set(key, value); err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceMarketCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceMarketAttributes())
+	relationships := blockValues(d, "relationships", resourceMarketRelationships())
 
 	marketCreate := commercelayer.MarketCreate{
 		Data: commercelayer.MarketCreateData{
@@ -174,7 +254,7 @@ func resourceMarketCreateFunc(ctx context.Context, d *schema.ResourceData, i int
 				ShippingCostCutoff:         intToInt32Ref(attributes["shipping_cost_cutoff"]),
 				Reference:                  stringRef(attributes["reference"]),
 				ReferenceOrigin:            stringRef(attributes["reference_origin"]),
-				Metadata:                   keyValueRef(attributes["metadata"]),
+				Metadata:                   metadataRef(attributes),
 			},
 			Relationships: &commercelayer.MarketCreateDataRelationships{
 				Merchant: commercelayer.MarketCreateDataRelationshipsMerchant{
@@ -217,11 +297,43 @@ func resourceMarketCreateFunc(ctx context.Context, d *schema.ResourceData, i int
 			}}
 	}
 
+	subscriptionModelId := stringRef(relationships["subscription_model_id"])
+	if subscriptionModelId != nil {
+		marketCreate.Data.Relationships.SubscriptionModel = &commercelayer.MarketCreateDataRelationshipsSubscriptionModel{
+			Data: commercelayer.MarketDataRelationshipsSubscriptionModelData{
+				Type: stringRef(subscriptionModelsType),
+				Id:   subscriptionModelId,
+			}}
+	}
+
+	geocoderId := stringRef(relationships["geocoder_id"])
+	if geocoderId != nil {
+		marketCreate.Data.Relationships.Geocoder = &commercelayer.AddressCreateDataRelationshipsGeocoder{
+			Data: commercelayer.AddressDataRelationshipsGeocoderData{
+				Type: stringRef(geocoderType),
+				Id:   geocoderId,
+			}}
+	}
+
+	if !attributes["enabled"].(bool) {
+		marketCreate.Data.Attributes.Disable = true
+	} else {
+		marketCreate.Data.Attributes.Enable = true
+	}
+
 	err := d.Set("type", marketType)
 	if err != nil {
 		return diagErr(err)
 	}
 
+	adopted, diags := adoptExisting(ctx, c, d, marketType, "code")
+	if diags.HasError() {
+		return diags
+	}
+	if adopted {
+		return append(diags, resourceMarketUpdateFunc(ctx, d, i)...)
+	}
+
 	market, _, err := c.MarketsApi.POSTMarkets(ctx).MarketCreate(marketCreate).Execute()
 	if err != nil {
 		return diagErr(err)
@@ -229,62 +341,87 @@ func resourceMarketCreateFunc(ctx context.Context, d *schema.ResourceData, i int
 
 	d.SetId(market.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, marketType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	basePriceListId := stringRef(relationships["base_price_list_id"])
+	if basePriceListId != nil {
+		err = patchToOneRelationship(ctx, c, marketType, d.Id(), "base_price_list", priceListType, basePriceListId)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceMarketDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.MarketsApi.DELETEMarketsMarketId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, marketType)
 	return diag.FromErr(err)
 }
 
 func resourceMarketUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceMarketAttributes())
+	relationships := blockValues(d, "relationships", resourceMarketRelationships())
 
 	var marketUpdate = commercelayer.MarketUpdate{
 		Data: commercelayer.MarketUpdateData{
 			Type: marketType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHMarketsMarketId200ResponseDataAttributes{
-				Name:                       stringRef(attributes["name"]),
-				Code:                       stringRef(attributes["code"]),
-				FacebookPixelId:            stringRef(attributes["facebook_pixel_id"]),
-				CheckoutUrl:                stringRef(attributes["checkout_url"]),
-				ExternalPricesUrl:          stringRef(attributes["external_prices_url"]),
-				ExternalOrderValidationUrl: stringRef(attributes["external_order_validation_url"]),
-				ShippingCostCutoff:         intToInt32Ref(attributes["shipping_cost_cutoff"]),
-				Reference:                  stringRef(attributes["reference"]),
-				ReferenceOrigin:            stringRef(attributes["reference_origin"]),
-				Metadata:                   keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.MarketUpdateDataRelationships{
-				Merchant: &commercelayer.MarketCreateDataRelationshipsMerchant{
-					Data: commercelayer.MarketDataRelationshipsMerchantData{
-						Type: stringRef(merchantType),
-						Id:   stringRef(relationships["merchant_id"]),
-					},
-				},
-				PriceList: &commercelayer.MarketCreateDataRelationshipsPriceList{
-					Data: commercelayer.MarketDataRelationshipsPriceListData{
-						Type: stringRef(priceListType),
-						Id:   stringRef(relationships["price_list_id"]),
-					},
-				},
-				InventoryModel: &commercelayer.InventoryReturnLocationCreateDataRelationshipsInventoryModel{
-					Data: commercelayer.InventoryReturnLocationDataRelationshipsInventoryModelData{
-						Type: stringRef(inventoryModelType),
-						Id:   stringRef(relationships["inventory_model_id"]),
-					},
-				},
+				Name:                       changedAttribute(d, "name", stringRef(attributes["name"])),
+				Code:                       changedAttribute(d, "code", stringRef(attributes["code"])),
+				FacebookPixelId:            changedAttribute(d, "facebook_pixel_id", stringRef(attributes["facebook_pixel_id"])),
+				CheckoutUrl:                changedAttribute(d, "checkout_url", stringRef(attributes["checkout_url"])),
+				ExternalPricesUrl:          changedAttribute(d, "external_prices_url", stringRef(attributes["external_prices_url"])),
+				ExternalOrderValidationUrl: changedAttribute(d, "external_order_validation_url", stringRef(attributes["external_order_validation_url"])),
+				ShippingCostCutoff:         changedAttribute(d, "shipping_cost_cutoff", configuredInt32Ref(d, "shipping_cost_cutoff")),
+				Reference:                  changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:            changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.MarketUpdateDataRelationships{},
 		},
 	}
 
+	if hasRelationshipChange(d, "merchant_id") {
+		marketUpdate.Data.Relationships.Merchant = &commercelayer.MarketCreateDataRelationshipsMerchant{
+			Data: commercelayer.MarketDataRelationshipsMerchantData{
+				Type: stringRef(merchantType),
+				Id:   stringRef(relationships["merchant_id"]),
+			},
+		}
+	}
+
+	if hasRelationshipChange(d, "price_list_id") {
+		marketUpdate.Data.Relationships.PriceList = &commercelayer.MarketCreateDataRelationshipsPriceList{
+			Data: commercelayer.MarketDataRelationshipsPriceListData{
+				Type: stringRef(priceListType),
+				Id:   stringRef(relationships["price_list_id"]),
+			},
+		}
+	}
+
+	if hasRelationshipChange(d, "inventory_model_id") {
+		marketUpdate.Data.Relationships.InventoryModel = &commercelayer.InventoryReturnLocationCreateDataRelationshipsInventoryModel{
+			Data: commercelayer.InventoryReturnLocationDataRelationshipsInventoryModelData{
+				Type: stringRef(inventoryModelType),
+				Id:   stringRef(relationships["inventory_model_id"]),
+			},
+		}
+	}
+
 	taxCalculatorId := stringRef(relationships["tax_calculator_id"])
-	if taxCalculatorId != nil {
+	if taxCalculatorId != nil && hasRelationshipChange(d, "tax_calculator_id") {
 		marketUpdate.Data.Relationships.TaxCalculator = &commercelayer.MarketCreateDataRelationshipsTaxCalculator{
 			Data: commercelayer.MarketDataRelationshipsTaxCalculatorData{
 				Type: stringRef(taxCalculatorType),
@@ -293,7 +430,7 @@ func resourceMarketUpdateFunc(ctx context.Context, d *schema.ResourceData, i int
 	}
 
 	customerGroupId := stringRef(relationships["customer_group_id"])
-	if customerGroupId != nil {
+	if customerGroupId != nil && hasRelationshipChange(d, "customer_group_id") {
 		marketUpdate.Data.Relationships.CustomerGroup = &commercelayer.CustomerCreateDataRelationshipsCustomerGroup{
 			Data: commercelayer.CustomerDataRelationshipsCustomerGroupData{
 				Type: stringRef(customerGroupType),
@@ -301,7 +438,62 @@ func resourceMarketUpdateFunc(ctx context.Context, d *schema.ResourceData, i int
 			}}
 	}
 
+	subscriptionModelId := stringRef(relationships["subscription_model_id"])
+	if subscriptionModelId != nil && hasRelationshipChange(d, "subscription_model_id") {
+		marketUpdate.Data.Relationships.SubscriptionModel = &commercelayer.MarketCreateDataRelationshipsSubscriptionModel{
+			Data: commercelayer.MarketDataRelationshipsSubscriptionModelData{
+				Type: stringRef(subscriptionModelsType),
+				Id:   subscriptionModelId,
+			}}
+	}
+
+	geocoderId := stringRef(relationships["geocoder_id"])
+	if geocoderId != nil && hasRelationshipChange(d, "geocoder_id") {
+		marketUpdate.Data.Relationships.Geocoder = &commercelayer.AddressCreateDataRelationshipsGeocoder{
+			Data: commercelayer.AddressDataRelationshipsGeocoderData{
+				Type: stringRef(geocoderType),
+				Id:   geocoderId,
+			}}
+	}
+
+	if d.IsNewResource() || hasAttributeChange(d, "enabled") {
+		if !attributes["enabled"].(bool) {
+			marketUpdate.Data.Attributes.Disable = true
+		} else {
+			marketUpdate.Data.Attributes.Enable = true
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, marketType)
+		if err != nil {
+			return diagErr(err)
+		}
+		marketUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.MarketsApi.PATCHMarketsMarketId(ctx, d.Id()).MarketUpdate(marketUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	err = clearRemovedRelationships(ctx, c, d, marketType, map[string]string{
+		"customer_group_id":     "customer_group",
+		"tax_calculator_id":     "tax_calculator",
+		"subscription_model_id": "subscription_model",
+		"geocoder_id":           "geocoder",
+	})
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if hasRelationshipChange(d, "base_price_list_id") {
+		err = patchToOneRelationship(ctx, c, marketType, d.Id(), "base_price_list", priceListType,
+			stringRef(relationships["base_price_list_id"]))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
@@ -1,25 +1,17 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 	"strings"
 )
 
 func testAccCheckMarketDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_market" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.MarketsApi.
-					GETMarketsMarketId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -36,8 +28,8 @@ func (s *AcceptanceSuite) TestAccMarket_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckMarketDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckMarketDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{
@@ -49,8 +41,10 @@ func (s *AcceptanceSuite) TestAccMarket_basic() {
 					testAccMarketCreate(resourceName)}, "\n",
 				),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Market"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.facebook_pixel_id", "pixel"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Market"),
+					resource.TestCheckResourceAttr(resourceName, "facebook_pixel_id", "pixel"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "private", "false"),
 				),
 			},
 			{
@@ -63,8 +57,8 @@ func (s *AcceptanceSuite) TestAccMarket_basic() {
 					testAccMarketUpdate(resourceName)}, "\n",
 				),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Market Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.facebook_pixel_id", "pixelchanged"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Market Changed"),
+					resource.TestCheckResourceAttr(resourceName, "facebook_pixel_id", "pixelchanged"),
 				),
 			},
 		},
@@ -74,7 +68,6 @@ func (s *AcceptanceSuite) TestAccMarket_basic() {
 func testAccMarketCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_market" "incentro_market" {
-		  attributes {
 			code = "M-001"
 			name              = "Incentro Market"
 			facebook_pixel_id = "pixel"
@@ -87,13 +80,10 @@ func testAccMarketCreate(testName string) string {
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 		
-		  relationships {
 			inventory_model_id = commercelayer_inventory_model.incentro_inventory_model.id
 			merchant_id        = commercelayer_merchant.incentro_merchant.id
 			price_list_id      = commercelayer_price_list.incentro_price_list.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -101,7 +91,6 @@ func testAccMarketCreate(testName string) string {
 func testAccMarketUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_market" "incentro_market" {
-		  attributes {
 			code = "M-001-CHG"
 			name              = "Incentro Market Changed"
 			facebook_pixel_id = "pixelchanged"
@@ -114,13 +103,10 @@ func testAccMarketUpdate(testName string) string {
 			metadata = {
 			  testName: "{{.testName}}"
 			}
-		  }
 		
-		  relationships {
 			inventory_model_id = commercelayer_inventory_model.incentro_inventory_model.id
 			merchant_id        = commercelayer_merchant.incentro_merchant.id
 			price_list_id      = commercelayer_price_list.incentro_price_list.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceMerchant() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "A merchant is the fiscal representative that is selling in a specific market. Tax calculators " +
 			"use the merchant's address (and the shipping address) to determine the tax rate for an order.",
 		ReadContext:   resourceMerchantReadFunc,
@@ -18,6 +18,7 @@ func resourceMerchant() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The merchant unique identifier",
@@ -29,57 +30,76 @@ func resourceMerchant() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The merchant's internal name",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceMerchantAttributes(),
+			"relationships": resourceMerchantRelationships(),
+		},
+	})
+}
+
+func resourceMerchantAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The merchant's internal name",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"address_id": {
-							Description: "The associated address id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceMerchantRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"address_id": {
+					Description: "The associated address id.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -87,9 +107,9 @@ func resourceMerchant() *schema.Resource {
 }
 
 func resourceMerchantReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.MerchantsApi.GETMerchantsMerchantId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.MerchantsApi.GETMerchantsMerchantId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -102,14 +122,24 @@ func resourceMerchantReadFunc(ctx context.Context, d *schema.ResourceData, i int
 
 	d.SetId(merchant.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceMerchantCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceMerchantAttributes())
+	relationships := blockValues(d, "relationships", resourceMerchantRelationships())
 
 	merchantCreate := commercelayer.MerchantCreate{
 		Data: commercelayer.MerchantCreateData{
@@ -118,7 +148,7 @@ func resourceMerchantCreateFunc(ctx context.Context, d *schema.ResourceData, i i
 				Name:            attributes["name"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 			Relationships: &commercelayer.MerchantCreateDataRelationships{
 				Address: commercelayer.CustomerAddressCreateDataRelationshipsAddress{
@@ -143,42 +173,61 @@ func resourceMerchantCreateFunc(ctx context.Context, d *schema.ResourceData, i i
 
 	d.SetId(merchant.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, merchantType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceMerchantDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.MerchantsApi.DELETEMerchantsMerchantId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, merchantType)
 	return diag.FromErr(err)
 }
 
 func resourceMerchantUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceMerchantAttributes())
+	relationships := blockValues(d, "relationships", resourceMerchantRelationships())
 
 	var merchantUpdate = commercelayer.MerchantUpdate{
 		Data: commercelayer.MerchantUpdateData{
 			Type: merchantType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHMerchantsMerchantId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"].(string)),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.MerchantUpdateDataRelationships{
-				Address: &commercelayer.CustomerAddressCreateDataRelationshipsAddress{
-					Data: commercelayer.BingGeocoderDataRelationshipsAddressesData{
-						Type: stringRef(addressType),
-						Id:   stringRef(relationships["address_id"]),
-					},
-				},
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"].(string))),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.MerchantUpdateDataRelationships{},
 		},
 	}
 
+	if hasRelationshipChange(d, "address_id") {
+		merchantUpdate.Data.Relationships.Address = &commercelayer.CustomerAddressCreateDataRelationshipsAddress{
+			Data: commercelayer.BingGeocoderDataRelationshipsAddressesData{
+				Type: stringRef(addressType),
+				Id:   stringRef(relationships["address_id"]),
+			},
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, merchantType)
+		if err != nil {
+			return diagErr(err)
+		}
+		merchantUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.MerchantsApi.PATCHMerchantsMerchantId(ctx, d.Id()).MerchantUpdate(merchantUpdate).Execute()
 
 	return diag.FromErr(err)
@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 	"strings"
 )
 
 func testAccCheckMerchantDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_merchant" {
@@ -50,22 +49,22 @@ func (s *AcceptanceSuite) TestAccMerchant_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckMerchantDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckMerchantDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{testAccAddressCreate(resourceName), testAccMerchantCreate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", merchantType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Merchant"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Merchant"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: strings.Join([]string{testAccAddressCreate(resourceName), testAccMerchantUpdate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Updated Merchant"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Updated Merchant"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -75,17 +74,13 @@ func (s *AcceptanceSuite) TestAccMerchant_basic() {
 func testAccMerchantCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_merchant" "incentro_merchant" {
-		  attributes {
 			name = "Incentro Merchant"
 			metadata = {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		
-		  relationships {
 			address_id = commercelayer_address.incentro_address.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -93,17 +88,13 @@ func testAccMerchantCreate(testName string) string {
 func testAccMerchantUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_merchant" "incentro_merchant" {
-		  attributes {
 			name = "Incentro Updated Merchant"
 			metadata = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		
-		  relationships {
 			address_id = commercelayer_address.incentro_address.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
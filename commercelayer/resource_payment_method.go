@@ -8,7 +8,7 @@ import (
 )
 
 func resourcePaymentMethod() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Payment methods represent the type of payment sources " +
 			"(e.g., Credit Card, PayPal, or Apple Pay) offered in a market. " +
 			"They can have a price and must be present before placing an order.",
@@ -16,9 +16,11 @@ func resourcePaymentMethod() *schema.Resource {
 		CreateContext: resourcePaymentMethodCreateFunc,
 		UpdateContext: resourcePaymentMethodUpdateFunc,
 		DeleteContext: resourcePaymentMethodDeleteFunc,
+		CustomizeDiff: resourcePaymentMethodCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The payment method unique identifier",
@@ -30,83 +32,136 @@ func resourcePaymentMethod() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"payment_source_type": {
-							Description: "The payment source type, can be one of: AdyenPayment, BraintreePayment, " +
-								"CheckoutComPayment, CreditCard, ExternalPayment, KlarnaPayment, PaypalPayment, " +
-								"StripePayment or WireTransfer",
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: paymentSourceValidation,
-						},
-						"currency_code": {
-							Description: "The international 3-letter currency code as defined by the ISO 4217 standard. " +
-								"Required, unless inherited by market",
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"moto": {
-							Description: "Send this attribute if you want to mark the payment as MOTO, " +
-								"must be supported by payment gateway.",
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
-						},
-						"price_amount_cents": {
-							Description: "The payment method's price, in cents.",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourcePaymentMethodAttributes(),
+			"relationships": resourcePaymentMethodRelationships(),
+		},
+	})
+}
+
+func resourcePaymentMethodAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"payment_source_type": {
+					Description: "The payment source type, can be one of: AdyenPayment, AxervePayment, " +
+						"BraintreePayment, CheckoutComPayment, CreditCard, ExternalPayment, KlarnaPayment, " +
+						"PaypalPayment, SatispayPayment, StripePayment or WireTransfer",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: paymentSourceValidation,
+				},
+				"currency_code": {
+					Description: "The international 3-letter currency code as defined by the ISO 4217 standard. " +
+						"Required, unless inherited by market",
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"moto": {
+					Description: "Send this attribute if you want to mark the payment as MOTO, " +
+						"must be supported by payment gateway.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"require_capture": {
+					Description: "Send this attribute if you want to require the payment capture before " +
+						"fulfillment.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"auto_place": {
+					Description: "Send this attribute if you want to automatically place the order upon " +
+						"authorization performed asynchronously.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"auto_capture": {
+					Description: "Send this attribute if you want to automatically capture the payment upon " +
+						"authorization.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"auto_capture_max_amount_cents": {
+					Description: "The maximum amount in cents that will be automatically captured upon " +
+						"authorization, can only be set when auto_capture is true.",
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"price_amount_cents": {
+					Description: "The payment method's price, in cents.",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment method is enabled, payment methods that aren't " +
+						"enabled are not offered at checkout.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"market_id": {
-							Description: "The associated market.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"payment_gateway_id": {
-							Description: "The associated payment gateway.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourcePaymentMethodRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"market_id": {
+					Description: "The associated market.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"payment_gateway_id": {
+					Description: "The associated payment gateway.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -114,41 +169,61 @@ func resourcePaymentMethod() *schema.Resource {
 }
 
 func resourcePaymentMethodReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.PaymentMethodsApi.GETPaymentMethodsPaymentMethodId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.PaymentMethodsApi.GETPaymentMethodsPaymentMethodId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
 
-	address, ok := resp.GetDataOk()
+	paymentMethod, ok := resp.GetDataOk()
 	if !ok {
 		d.SetId("")
 		return nil
 	}
 
-	d.SetId(address.GetId().(string))
+	d.SetId(paymentMethod.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	attributes := paymentMethod.GetAttributes()
+	err = setEnabled(d, attributes.GetDisabledAt())
+	if err != nil {
+		return diagErr(err)
+	}
 
 	return nil
 }
 
 func resourcePaymentMethodCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourcePaymentMethodAttributes())
+	relationships := blockValues(d, "relationships", resourcePaymentMethodRelationships())
 
 	paymentMethodCreate := commercelayer.PaymentMethodCreate{
 		Data: commercelayer.PaymentMethodCreateData{
 			Type: paymentMethodType,
 			Attributes: commercelayer.POSTPaymentMethods201ResponseDataAttributes{
-				PaymentSourceType: attributes["payment_source_type"].(string),
-				CurrencyCode:      stringRef(attributes["currency_code"]),
-				Moto:              boolRef(attributes["moto"]),
-				PriceAmountCents:  int32(attributes["price_amount_cents"].(int)),
-				Reference:         stringRef(attributes["reference"]),
-				ReferenceOrigin:   stringRef(attributes["reference_origin"]),
-				Metadata:          keyValueRef(attributes["metadata"]),
+				PaymentSourceType:         attributes["payment_source_type"].(string),
+				CurrencyCode:              stringRef(attributes["currency_code"]),
+				Moto:                      boolRef(attributes["moto"]),
+				RequireCapture:            boolRef(attributes["require_capture"]),
+				AutoPlace:                 boolRef(attributes["auto_place"]),
+				AutoCapture:               boolRef(attributes["auto_capture"]),
+				AutoCaptureMaxAmountCents: intToInt32Ref(attributes["auto_capture_max_amount_cents"]),
+				PriceAmountCents:          int32(attributes["price_amount_cents"].(int)),
+				Reference:                 stringRef(attributes["reference"]),
+				ReferenceOrigin:           stringRef(attributes["reference_origin"]),
+				Metadata:                  metadataRef(attributes),
 			},
 			Relationships: &commercelayer.PaymentMethodCreateDataRelationships{
 				PaymentGateway: commercelayer.PaymentMethodCreateDataRelationshipsPaymentGateway{
@@ -180,6 +255,12 @@ func resourcePaymentMethodCreateFunc(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	if !attributes["enabled"].(bool) {
+		paymentMethodCreate.Data.Attributes.Disable = true
+	} else {
+		paymentMethodCreate.Data.Attributes.Enable = true
+	}
+
 	err := d.Set("type", paymentMethodType)
 	if err != nil {
 		return diagErr(err)
@@ -192,47 +273,53 @@ func resourcePaymentMethodCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(paymentMethod.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, paymentMethodType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourcePaymentMethodDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.PaymentMethodsApi.DELETEPaymentMethodsPaymentMethodId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, paymentMethodType)
 	return diag.FromErr(err)
 }
 
 func resourcePaymentMethodUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourcePaymentMethodAttributes())
+	relationships := blockValues(d, "relationships", resourcePaymentMethodRelationships())
 
 	var paymentMethodUpdate = commercelayer.PaymentMethodUpdate{
 		Data: commercelayer.PaymentMethodUpdateData{
 			Type: paymentMethodType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHPaymentMethodsPaymentMethodId200ResponseDataAttributes{
-				PaymentSourceType: stringRef(attributes["payment_source_type"]),
-				CurrencyCode:      stringRef(attributes["currency_code"]),
-				Moto:              boolRef(attributes["moto"]),
-				PriceAmountCents:  intToInt32Ref(attributes["price_amount_cents"]),
-				Reference:         stringRef(attributes["reference"]),
-				ReferenceOrigin:   stringRef(attributes["reference_origin"]),
-				Metadata:          keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.PaymentMethodUpdateDataRelationships{
-				PaymentGateway: &commercelayer.PaymentMethodCreateDataRelationshipsPaymentGateway{
-					Data: commercelayer.AdyenPaymentDataRelationshipsPaymentGatewayData{
-						Type: stringRef(adyenGatewaysType),
-						Id:   stringRef(relationships["payment_gateway_id"]),
-					},
-				},
+				PaymentSourceType:         changedAttribute(d, "payment_source_type", stringRef(attributes["payment_source_type"])),
+				CurrencyCode:              changedAttribute(d, "currency_code", stringRef(attributes["currency_code"])),
+				Moto:                      changedAttribute(d, "moto", boolRef(attributes["moto"])),
+				RequireCapture:            changedAttribute(d, "require_capture", boolRef(attributes["require_capture"])),
+				AutoPlace:                 changedAttribute(d, "auto_place", boolRef(attributes["auto_place"])),
+				AutoCapture:               changedAttribute(d, "auto_capture", boolRef(attributes["auto_capture"])),
+				AutoCaptureMaxAmountCents: changedAttribute(d, "auto_capture_max_amount_cents", configuredInt32Ref(d, "auto_capture_max_amount_cents")),
+				PriceAmountCents:          changedAttribute(d, "price_amount_cents", configuredInt32Ref(d, "price_amount_cents")),
+				Reference:                 changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:           changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.PaymentMethodUpdateDataRelationships{},
 		},
 	}
 
 	marketId := stringRef(relationships["market_id"])
-	if marketId != nil {
+	if marketId != nil && hasRelationshipChange(d, "market_id") {
 		paymentMethodUpdate.Data.Relationships.Market = &commercelayer.BillingInfoValidationRuleCreateDataRelationshipsMarket{
 			Data: commercelayer.AvalaraAccountDataRelationshipsMarketsData{
 				Type: stringRef(marketType),
@@ -241,7 +328,7 @@ func resourcePaymentMethodUpdateFunc(ctx context.Context, d *schema.ResourceData
 	}
 
 	paymentGatewayId := stringRef(relationships["payment_gateway_id"])
-	if paymentGatewayId != nil {
+	if paymentGatewayId != nil && hasRelationshipChange(d, "payment_gateway_id") {
 		paymentMethodUpdate.Data.Relationships.PaymentGateway =
 			&commercelayer.PaymentMethodCreateDataRelationshipsPaymentGateway{
 				Data: commercelayer.AdyenPaymentDataRelationshipsPaymentGatewayData{
@@ -251,8 +338,43 @@ func resourcePaymentMethodUpdateFunc(ctx context.Context, d *schema.ResourceData
 			}
 	}
 
+	if hasAttributeChange(d, "enabled") {
+		if !attributes["enabled"].(bool) {
+			paymentMethodUpdate.Data.Attributes.Disable = true
+		} else {
+			paymentMethodUpdate.Data.Attributes.Enable = true
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, paymentMethodType)
+		if err != nil {
+			return diagErr(err)
+		}
+		paymentMethodUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.PaymentMethodsApi.PATCHPaymentMethodsPaymentMethodId(ctx, d.Id()).PaymentMethodUpdate(paymentMethodUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	err = clearRemovedRelationships(ctx, c, d, paymentMethodType, map[string]string{
+		"market_id": "market",
+	})
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourcePaymentMethodCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(attributeKey(d, "auto_capture")) ||
+		!d.NewValueKnown(attributeKey(d, "auto_capture_max_amount_cents")) {
+		return nil
+	}
 
+	return validateAutoCaptureMaxAmount(d.Get(attributeKey(d, "auto_capture")).(bool),
+		d.Get(attributeKey(d, "auto_capture_max_amount_cents")).(int))
 }
@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 	"strings"
 )
 
 func testAccCheckPaymentMethodDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_payment_method" {
@@ -63,24 +62,30 @@ func (s *AcceptanceSuite) TestAccPaymentMethod_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckPaymentMethodDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckPaymentMethodDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{testAccAdyenGatewayCreate(resourceName), testAccPaymentMethodCreate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", paymentMethodType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.currency_code", "EUR"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.payment_source_type", "AdyenPayment"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.price_amount_cents", "10"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "currency_code", "EUR"),
+					resource.TestCheckResourceAttr(resourceName, "payment_source_type", "AdyenPayment"),
+					resource.TestCheckResourceAttr(resourceName, "price_amount_cents", "10"),
+					resource.TestCheckResourceAttr(resourceName, "auto_place", "true"),
+					resource.TestCheckResourceAttr(resourceName, "auto_capture", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 				),
 			},
 			{
 				Config: strings.Join([]string{testAccAdyenGatewayCreate(resourceName), testAccPaymentMethodUpdate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.price_amount_cents", "5"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "price_amount_cents", "5"),
+					resource.TestCheckResourceAttr(resourceName, "require_capture", "true"),
+					resource.TestCheckResourceAttr(resourceName, "auto_capture", "true"),
+					resource.TestCheckResourceAttr(resourceName, "auto_capture_max_amount_cents", "10000"),
 				),
 			},
 		},
@@ -90,7 +95,6 @@ func (s *AcceptanceSuite) TestAccPaymentMethod_basic() {
 func testAccPaymentMethodCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_payment_method" "incentro_payment_method" {
-		  attributes {
       		payment_source_type   = "AdyenPayment"
 			currency_code          = "EUR"
 			price_amount_cents     = 10
@@ -98,11 +102,8 @@ func testAccPaymentMethodCreate(testName string) string {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 
-		  relationships {
 			payment_gateway_id = commercelayer_adyen_gateway.incentro_adyen_gateway.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -110,18 +111,17 @@ func testAccPaymentMethodCreate(testName string) string {
 func testAccPaymentMethodUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_payment_method" "incentro_payment_method" {
-		  attributes {
       		payment_source_type    = "AdyenPayment"
 			currency_code          = "EUR"
 			price_amount_cents     = 5
+			require_capture        = true
+			auto_capture           = true
+			auto_capture_max_amount_cents = 10000
 			metadata               = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
-  		  relationships {
 			payment_gateway_id = commercelayer_adyen_gateway.incentro_adyen_gateway.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourcePaypalGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "Configuring a PayPal payment gateway for a market lets you safely process payments " +
 			"through PayPal.To create a PayPal gateway choose a meaningful name that helps you identify it within " +
 			"your organization and connect your PayPal account by adding your client ID and secret " +
@@ -20,6 +20,7 @@ func resourcePaypalGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The paypal payment unique identifier",
@@ -31,51 +32,73 @@ func resourcePaypalGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"client_id": {
-							Description: "The gateway client ID.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"client_secret": {
-							Description: "The gateway client secret.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourcePaypalGatewayAttributes(),
+		},
+	}))
+}
+
+func resourcePaypalGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"client_id": {
+					Description: "The gateway client ID.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"client_secret": {
+					Description: "The gateway client secret.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -83,9 +106,9 @@ func resourcePaypalGateway() *schema.Resource {
 }
 
 func resourcePaypalGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.PaypalGatewaysApi.GETPaypalGatewaysPaypalGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.PaypalGatewaysApi.GETPaypalGatewaysPaypalGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -98,13 +121,33 @@ func resourcePaypalGatewayReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(paypalGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourcePaypalGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourcePaypalGatewayAttributes())
 
 	paypalGatewayCreate := commercelayer.PaypalGatewayCreate{
 		Data: commercelayer.PaypalGatewayCreateData{
@@ -115,7 +158,7 @@ func resourcePaypalGatewayCreateFunc(ctx context.Context, d *schema.ResourceData
 				ClientSecret:    attributes["client_secret"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -132,37 +175,71 @@ func resourcePaypalGatewayCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(paypalGateway.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, paypalGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, paypalGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourcePaypalGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.PaypalGatewaysApi.DELETEPaypalGatewaysPaypalGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, paypalGatewaysType)
 	return diag.FromErr(err)
 }
 
 func resourcePaypalGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourcePaypalGatewayAttributes())
 
 	var paypalGatewayUpdate = commercelayer.PaypalGatewayUpdate{
 		Data: commercelayer.PaypalGatewayUpdateData{
 			Type: paypalGatewaysType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHPaypalGatewaysPaypalGatewayId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				ClientId:        stringRef(attributes["client_id"]),
-				ClientSecret:    stringRef(attributes["client_secret"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				ClientId:        changedAttribute(d, "client_id", stringRef(attributes["client_id"])),
+				ClientSecret:    changedAttribute(d, "client_secret", stringRef(attributes["client_secret"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, paypalGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		paypalGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.PaypalGatewaysApi.PATCHPaypalGatewaysPaypalGatewayId(ctx, d.Id()).
 		PaypalGatewayUpdate(paypalGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, paypalGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
 }
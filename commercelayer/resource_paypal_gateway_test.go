@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckPaypalGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_paypal_gateway" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccPaypalGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckPaypalGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckPaypalGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccPaypalGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", paypalGatewaysType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Paypal Gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Paypal Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccPaypalGatewayUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Paypal Gateway Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Paypal Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,7 +61,6 @@ func (s *AcceptanceSuite) TestAccPaypalGateway_basic() {
 func testAccPaypalGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_paypal_gateway" "incentro_paypal_gateway" {
-           attributes {
 			name                   = "Incentro Paypal Gateway"
 			client_id              = "xxxx-yyyy-zzzz"
 			client_secret          = "xxxx-yyyy-zzzz"
@@ -71,7 +69,6 @@ func testAccPaypalGatewayCreate(testName string) string {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -79,7 +76,6 @@ func testAccPaypalGatewayCreate(testName string) string {
 func testAccPaypalGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_paypal_gateway" "incentro_paypal_gateway" {
-           attributes {
 			name                   = "Incentro Paypal Gateway Changed"
 			client_id              = "xxxx-yyyy-zzzz"
 			client_secret          = "xxxx-yyyy-zzzz"
@@ -88,7 +84,6 @@ func testAccPaypalGatewayUpdate(testName string) string {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourcePriceList() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: `Price lists are collections of SKU prices, 
 		defined by currency and market. When a list of SKUs is fetched, 
 		only SKUs with a price defined in the market's price list and at least 
@@ -21,6 +21,7 @@ func resourcePriceList() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The PriceList unique identifier",
@@ -32,51 +33,69 @@ func resourcePriceList() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The price list's internal name",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"currency_code": {
-							Description:      "The international 3-letter currency code as defined by the ISO 4217 standard.",
-							Type:             schema.TypeString,
-							Required:         true,
-							ValidateDiagFunc: currencyCodeValidation,
-						},
-						"tax_included": {
-							Description: "Indicates if the associated prices include taxes.",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"adopt_existing": adoptExistingSchema("price list", "reference"),
+			"attributes":     resourcePriceListAttributes(),
+		},
+	}))
+}
+
+func resourcePriceListAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The price list's internal name",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"currency_code": {
+					Description:      "The international 3-letter currency code as defined by the ISO 4217 standard.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: currencyCodeValidation,
+				},
+				"tax_included": {
+					Description: "Indicates if the associated prices include taxes.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -84,9 +103,9 @@ func resourcePriceList() *schema.Resource {
 }
 
 func resourcePriceListReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.PriceListsApi.GETPriceListsPriceListId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.PriceListsApi.GETPriceListsPriceListId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -99,13 +118,23 @@ func resourcePriceListReadFunc(ctx context.Context, d *schema.ResourceData, i in
 
 	d.SetId(priceList.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourcePriceListCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourcePriceListAttributes())
 
 	priceListCreate := commercelayer.PriceListCreate{
 		Data: commercelayer.PriceListCreateData{
@@ -116,7 +145,7 @@ func resourcePriceListCreateFunc(ctx context.Context, d *schema.ResourceData, i
 				TaxIncluded:     boolRef(attributes["tax_included"]),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -126,6 +155,14 @@ func resourcePriceListCreateFunc(ctx context.Context, d *schema.ResourceData, i
 		return diagErr(err)
 	}
 
+	adopted, diags := adoptExisting(ctx, c, d, priceListType, "reference")
+	if diags.HasError() {
+		return diags
+	}
+	if adopted {
+		return append(diags, resourcePriceListUpdateFunc(ctx, d, i)...)
+	}
+
 	priceList, _, err := c.PriceListsApi.POSTPriceLists(ctx).PriceListCreate(priceListCreate).Execute()
 	if err != nil {
 		return diagErr(err)
@@ -133,35 +170,52 @@ func resourcePriceListCreateFunc(ctx context.Context, d *schema.ResourceData, i
 
 	d.SetId(priceList.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, priceListType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourcePriceListDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.PriceListsApi.DELETEPriceListsPriceListId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, priceListType)
 	return diag.FromErr(err)
 }
 
 func resourcePriceListUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourcePriceListAttributes())
 
 	var priceListUpdate = commercelayer.PriceListUpdate{
 		Data: commercelayer.PriceListUpdateData{
 			Type: priceListType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHPriceListsPriceListId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"].(string)),
-				CurrencyCode:    stringRef(attributes["currency_code"].(string)),
-				TaxIncluded:     boolRef(attributes["tax_included"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"].(string))),
+				CurrencyCode:    changedAttribute(d, "currency_code", stringRef(attributes["currency_code"].(string))),
+				TaxIncluded:     changedAttribute(d, "tax_included", boolRef(attributes["tax_included"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, priceListType)
+		if err != nil {
+			return diagErr(err)
+		}
+		priceListUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.PriceListsApi.PATCHPriceListsPriceListId(ctx, d.Id()).PriceListUpdate(priceListUpdate).Execute()
 
 	return diag.FromErr(err)
@@ -0,0 +1,298 @@
+package commercelayer
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+func resourcePriceListScheduler() *schema.Resource {
+	return flattenedResource(&schema.Resource{
+		Description: "Price list schedulers activate a price list on a market for a limited period of time. " +
+			"While a scheduler is active its price list replaces the one of the market, after it expires the " +
+			"market falls back to its base price list.",
+		ReadContext:   resourcePriceListSchedulerReadFunc,
+		CreateContext: resourcePriceListSchedulerCreateFunc,
+		UpdateContext: resourcePriceListSchedulerUpdateFunc,
+		DeleteContext: resourcePriceListSchedulerDeleteFunc,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The price list scheduler unique identifier",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "The resource type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes":    resourcePriceListSchedulerAttributes(),
+			"relationships": resourcePriceListSchedulerRelationships(),
+		},
+	})
+}
+
+func resourcePriceListSchedulerAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The price list scheduler's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"starts_at": {
+					Description:      "The activation date/time of this price list scheduler, as RFC 3339 timestamp.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: rfc3339Validation,
+				},
+				"expires_at": {
+					Description:      "The expiration date/time of this price list scheduler, as RFC 3339 timestamp.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: rfc3339Validation,
+				},
+				"enabled": {
+					Description: "Indicates if the price list scheduler is enabled, disabled schedulers " +
+						"never activate their price list.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+			},
+		},
+	}
+}
+
+func resourcePriceListSchedulerRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"market_id": {
+					Description: "The associated market id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"price_list_id": {
+					Description: "The associated price list id.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+func resourcePriceListSchedulerReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	resp, httpResp, err := c.PriceListSchedulersApi.GETPriceListSchedulersPriceListSchedulerId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	priceListScheduler, ok := resp.GetDataOk()
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(priceListScheduler.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	attributes := priceListScheduler.GetAttributes()
+	err = setEnabled(d, attributes.GetDisabledAt())
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourcePriceListSchedulerCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourcePriceListSchedulerAttributes())
+	relationships := blockValues(d, "relationships", resourcePriceListSchedulerRelationships())
+
+	priceListSchedulerCreate := commercelayer.PriceListSchedulerCreate{
+		Data: commercelayer.PriceListSchedulerCreateData{
+			Type: priceListSchedulersType,
+			Attributes: commercelayer.POSTPriceListSchedulers201ResponseDataAttributes{
+				Name:            attributes["name"].(string),
+				StartsAt:        attributes["starts_at"].(string),
+				ExpiresAt:       attributes["expires_at"].(string),
+				Reference:       stringRef(attributes["reference"]),
+				ReferenceOrigin: stringRef(attributes["reference_origin"]),
+				Metadata:        metadataRef(attributes),
+			},
+			Relationships: &commercelayer.PriceListSchedulerCreateDataRelationships{
+				Market: commercelayer.BillingInfoValidationRuleCreateDataRelationshipsMarket{
+					Data: commercelayer.AvalaraAccountDataRelationshipsMarketsData{
+						Type: stringRef(marketType),
+						Id:   stringRef(relationships["market_id"]),
+					},
+				},
+				PriceList: commercelayer.MarketCreateDataRelationshipsPriceList{
+					Data: commercelayer.MarketDataRelationshipsPriceListData{
+						Type: stringRef(priceListType),
+						Id:   stringRef(relationships["price_list_id"]),
+					},
+				},
+			},
+		},
+	}
+
+	if !attributes["enabled"].(bool) {
+		priceListSchedulerCreate.Data.Attributes.Disable = true
+	}
+
+	err := d.Set("type", priceListSchedulersType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	priceListScheduler, _, err := c.PriceListSchedulersApi.POSTPriceListSchedulers(ctx).
+		PriceListSchedulerCreate(priceListSchedulerCreate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	d.SetId(priceListScheduler.Data.GetId().(string))
+
+	err = waitForCreated(ctx, c, d, priceListSchedulersType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourcePriceListSchedulerDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+	_, err := c.PriceListSchedulersApi.DELETEPriceListSchedulersPriceListSchedulerId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, priceListSchedulersType)
+	return diag.FromErr(err)
+}
+
+func resourcePriceListSchedulerUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourcePriceListSchedulerAttributes())
+	relationships := blockValues(d, "relationships", resourcePriceListSchedulerRelationships())
+
+	var priceListSchedulerUpdate = commercelayer.PriceListSchedulerUpdate{
+		Data: commercelayer.PriceListSchedulerUpdateData{
+			Type: priceListSchedulersType,
+			Id:   d.Id(),
+			Attributes: commercelayer.PATCHPriceListSchedulersPriceListSchedulerId200ResponseDataAttributes{
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				StartsAt:        changedAttribute(d, "starts_at", stringRef(attributes["starts_at"])),
+				ExpiresAt:       changedAttribute(d, "expires_at", stringRef(attributes["expires_at"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+			},
+			Relationships: &commercelayer.PriceListSchedulerUpdateDataRelationships{},
+		},
+	}
+
+	if hasRelationshipChange(d, "market_id") {
+		priceListSchedulerUpdate.Data.Relationships.Market = &commercelayer.BillingInfoValidationRuleCreateDataRelationshipsMarket{
+			Data: commercelayer.AvalaraAccountDataRelationshipsMarketsData{
+				Type: stringRef(marketType),
+				Id:   stringRef(relationships["market_id"]),
+			},
+		}
+	}
+
+	if hasRelationshipChange(d, "price_list_id") {
+		priceListSchedulerUpdate.Data.Relationships.PriceList = &commercelayer.MarketCreateDataRelationshipsPriceList{
+			Data: commercelayer.MarketDataRelationshipsPriceListData{
+				Type: stringRef(priceListType),
+				Id:   stringRef(relationships["price_list_id"]),
+			},
+		}
+	}
+
+	if hasAttributeChange(d, "enabled") {
+		if !attributes["enabled"].(bool) {
+			priceListSchedulerUpdate.Data.Attributes.Disable = true
+		} else {
+			priceListSchedulerUpdate.Data.Attributes.Enable = true
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, priceListSchedulersType)
+		if err != nil {
+			return diagErr(err)
+		}
+		priceListSchedulerUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	_, _, err := c.PriceListSchedulersApi.PATCHPriceListSchedulersPriceListSchedulerId(ctx, d.Id()).
+		PriceListSchedulerUpdate(priceListSchedulerUpdate).Execute()
+
+	return diag.FromErr(err)
+}
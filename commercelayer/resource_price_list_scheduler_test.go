@@ -0,0 +1,102 @@
+package commercelayer
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"strings"
+)
+
+func testAccCheckPriceListSchedulerDestroy(s *terraform.State) error {
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "commercelayer_price_list_scheduler" {
+			err := testAccWaitForRemoval(client, rs)
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func (s *AcceptanceSuite) TestAccPriceListScheduler_basic() {
+	resourceName := "commercelayer_price_list_scheduler.incentro_price_list_scheduler"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckPriceListSchedulerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Join([]string{
+					testAccAddressCreate(resourceName),
+					testAccInventoryModelCreate(resourceName),
+					testAccMerchantCreate(resourceName),
+					testAccPriceListCreate(resourceName),
+					testAccExternalTaxCalculatorCreate(resourceName),
+					testAccMarketCreate(resourceName),
+					testAccPriceListSchedulerCreate(resourceName)}, "\n",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", priceListSchedulersType),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Black Friday"),
+					resource.TestCheckResourceAttr(resourceName, "starts_at", "2024-11-29T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				Config: strings.Join([]string{
+					testAccAddressCreate(resourceName),
+					testAccInventoryModelCreate(resourceName),
+					testAccMerchantCreate(resourceName),
+					testAccPriceListCreate(resourceName),
+					testAccExternalTaxCalculatorCreate(resourceName),
+					testAccMarketCreate(resourceName),
+					testAccPriceListSchedulerUpdate(resourceName)}, "\n",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Cyber Monday"),
+					resource.TestCheckResourceAttr(resourceName, "expires_at", "2024-12-03T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPriceListSchedulerCreate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_price_list_scheduler" "incentro_price_list_scheduler" {
+			name       = "Incentro Black Friday"
+			starts_at  = "2024-11-29T00:00:00Z"
+			expires_at = "2024-11-30T00:00:00Z"
+			metadata = {
+			  testName: "{{.testName}}"
+			}
+
+			market_id     = commercelayer_market.incentro_market.id
+			price_list_id = commercelayer_price_list.incentro_price_list.id
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccPriceListSchedulerUpdate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_price_list_scheduler" "incentro_price_list_scheduler" {
+			name       = "Incentro Cyber Monday"
+			starts_at  = "2024-12-02T00:00:00Z"
+			expires_at = "2024-12-03T00:00:00Z"
+			enabled    = false
+			metadata = {
+			  testName: "{{.testName}}"
+			}
+
+			market_id     = commercelayer_market.incentro_market.id
+			price_list_id = commercelayer_price_list.incentro_price_list.id
+		}
+	`, map[string]any{"testName": testName})
+}
@@ -1,24 +1,17 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 	"regexp"
 )
 
 func testAccCheckPriceListDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_price_list" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.PriceListsApi.GETPriceListsPriceListId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -35,24 +28,24 @@ func (s *AcceptanceSuite) TestAccPriceList_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckPriceListDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckPriceListDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccPriceListCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", priceListType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro price list"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.currency_code", "EUR"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro price list"),
+					resource.TestCheckResourceAttr(resourceName, "currency_code", "EUR"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccPriceListUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro updated price list"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.currency_code", "CHF"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro updated price list"),
+					resource.TestCheckResourceAttr(resourceName, "currency_code", "CHF"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -66,8 +59,8 @@ func (s *AcceptanceSuite) TestAccPriceList_invalid() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckPriceListDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckPriceListDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccPriceListCreateInvalidCurrency(resourceName),
@@ -77,17 +70,42 @@ func (s *AcceptanceSuite) TestAccPriceList_invalid() {
 	})
 }
 
+func (s *AcceptanceSuite) TestAccPriceList_deletionProtection() {
+	resourceName := "commercelayer_price_list.incentro_price_list"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckPriceListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPriceListDeletionProtection(resourceName, true),
+				Check:  resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+			},
+			{
+				Config:      testAccPriceListDeletionProtection(resourceName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion protection is enabled"),
+			},
+			{
+				Config: testAccPriceListDeletionProtection(resourceName, false),
+				Check:  resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
 func testAccPriceListCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_price_list" "incentro_price_list" {
-		  attributes {
 			name          = "incentro price list"
 			currency_code = "EUR"
 			metadata = {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -95,14 +113,12 @@ func testAccPriceListCreate(testName string) string {
 func testAccPriceListUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_price_list" "incentro_price_list" {
-		  attributes {
 			name          = "incentro updated price list"
 			currency_code = "CHF"
 			metadata = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -110,14 +126,25 @@ func testAccPriceListUpdate(testName string) string {
 func testAccPriceListCreateInvalidCurrency(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_price_list" "incentro_price_list_invalid_currency" {
-		  attributes {
 			name          = "incentro updated price list"
 			currency_code = "FOOBAR"
 			metadata = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
+
+func testAccPriceListDeletionProtection(testName string, deletionProtection bool) string {
+	return hclTemplate(`
+		resource "commercelayer_price_list" "incentro_price_list" {
+			name                = "incentro price list"
+			currency_code       = "EUR"
+			deletion_protection = {{.deletionProtection}}
+			metadata = {
+		 	  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName, "deletionProtection": deletionProtection})
+}
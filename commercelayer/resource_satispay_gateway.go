@@ -0,0 +1,241 @@
+package commercelayer
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+func resourceSatispayGateway() *schema.Resource {
+	return protectedResource(flattenedResource(&schema.Resource{
+		Description: "Configuring a Satispay payment gateway for a market lets you safely process payments through " +
+			"Satispay. To create a Satispay gateway choose a meaningful name that helps you identify it within your " +
+			"organization and provide the activation code generated from the Satispay Dashboard.",
+		ReadContext:   resourceSatispayGatewayReadFunc,
+		CreateContext: resourceSatispayGatewayCreateFunc,
+		UpdateContext: resourceSatispayGatewayUpdateFunc,
+		DeleteContext: resourceSatispayGatewayDeleteFunc,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The satispay payment unique identifier",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "The resource type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes": resourceSatispayGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceSatispayGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"token": {
+					Description: "Activation code generated from the Satispay Dashboard. The code can only " +
+						"be used once, so changing it recreates the gateway.",
+					Type:      schema.TypeString,
+					Required:  true,
+					ForceNew:  true,
+					Sensitive: true,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+			},
+		},
+	}
+}
+
+func resourceSatispayGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	resp, httpResp, err := c.SatispayGatewaysApi.GETSatispayGatewaysSatispayGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	satispayGateway, ok := resp.GetDataOk()
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(satispayGateway.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceSatispayGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceSatispayGatewayAttributes())
+
+	satispayGatewayCreate := commercelayer.SatispayGatewayCreate{
+		Data: commercelayer.SatispayGatewayCreateData{
+			Type: satispayGatewaysType,
+			Attributes: commercelayer.POSTSatispayGateways201ResponseDataAttributes{
+				Name:            attributes["name"].(string),
+				Token:           attributes["token"].(string),
+				Reference:       stringRef(attributes["reference"]),
+				ReferenceOrigin: stringRef(attributes["reference_origin"]),
+				Metadata:        metadataRef(attributes),
+			},
+		},
+	}
+
+	err := d.Set("type", satispayGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	satispayGateway, _, err := c.SatispayGatewaysApi.POSTSatispayGateways(ctx).
+		SatispayGatewayCreate(satispayGatewayCreate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	d.SetId(satispayGateway.Data.GetId().(string))
+
+	err = waitForCreated(ctx, c, d, satispayGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, satispayGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSatispayGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+	_, err := c.SatispayGatewaysApi.DELETESatispayGatewaysSatispayGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, satispayGatewaysType)
+	return diag.FromErr(err)
+}
+
+func resourceSatispayGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceSatispayGatewayAttributes())
+
+	var satispayGatewayUpdate = commercelayer.SatispayGatewayUpdate{
+		Data: commercelayer.SatispayGatewayUpdateData{
+			Type: satispayGatewaysType,
+			Id:   d.Id(),
+			Attributes: commercelayer.PATCHManualGatewaysManualGatewayId200ResponseDataAttributes{
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+			},
+		},
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, satispayGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		satispayGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	_, _, err := c.SatispayGatewaysApi.PATCHSatispayGatewaysSatispayGatewayId(ctx, d.Id()).
+		SatispayGatewayUpdate(satispayGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, satispayGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccCheckSatispayGatewayDestroy(s *terraform.State) error {
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "commercelayer_satispay_gateway" {
+			_, resp, err := client.SatispayGatewaysApi.
+				GETSatispayGatewaysSatispayGatewayId(context.Background(), rs.Primary.ID).Execute()
+			if resp.StatusCode == 404 {
+				fmt.Printf("commercelayer_satispay_gateway with id %s has been removed\n", rs.Primary.ID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("received response code with status %d", resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+func (s *AcceptanceSuite) TestAccSatispayGateway_basic() {
+	resourceName := "commercelayer_satispay_gateway.incentro_satispay_gateway"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckSatispayGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSatispayGatewayCreate(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", satispayGatewaysType),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Satispay Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccSatispayGatewayUpdate(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Satispay Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSatispayGatewayCreate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_satispay_gateway" "incentro_satispay_gateway" {
+			name  = "Incentro Satispay Gateway"
+			token = "623ECX"
+
+			metadata = {
+			  foo: "bar"
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccSatispayGatewayUpdate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_satispay_gateway" "incentro_satispay_gateway" {
+			name  = "Incentro Satispay Gateway Changed"
+			token = "623ECX"
+
+			metadata = {
+			  bar: "foo"
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
+}
@@ -1,23 +1,16 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 )
 
 func testAccCheckShippingCategoryDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_shipping_category" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.ShippingCategoriesApi.GETShippingCategoriesShippingCategoryId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -34,22 +27,22 @@ func (s *AcceptanceSuite) TestAccShippingCategory_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckShippingCategoryDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckShippingCategoryDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccShippingCategoryCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", shippingCategoryType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Shipping Category"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Shipping Category"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccShippingCategoryUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Shipping Category Updated"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Shipping Category Updated"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -59,13 +52,11 @@ func (s *AcceptanceSuite) TestAccShippingCategory_basic() {
 func testAccShippingCategoryCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_shipping_category" "incentro_shipping_category" {
-		  attributes {
 			name                   = "Incentro Shipping Category"
 			metadata               = {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -73,13 +64,11 @@ func testAccShippingCategoryCreate(testName string) string {
 func testAccShippingCategoryUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_shipping_category" "incentro_shipping_category" {
-		  attributes {
 			name                   = "Incentro Shipping Category Updated"
 			metadata               = {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceShippingMethod() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description:   `Shipping methods are used to provide customers with different delivery options.`,
 		ReadContext:   resourceShippingMethodReadFunc,
 		CreateContext: resourceShippingMethodCreateFunc,
@@ -17,6 +17,7 @@ func resourceShippingMethod() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The shipping method unique identifier",
@@ -28,134 +29,155 @@ func resourceShippingMethod() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The shipping method's name",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"scheme": {
-							Description: "The shipping method's scheme, one of 'flat', 'weight_tiered' or 'external'.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "flat",
-						},
-						"currency_code": {
-							Description: "The international 3-letter currency code as defined by the ISO " +
-								"4217 standard.",
-							Type:             schema.TypeString,
-							Optional:         true,
-							ValidateDiagFunc: currencyCodeValidation,
-						},
-						"external_prices_url": {
-							Description: "Required, if external scheme",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"price_amount_cents": {
-							Description: "The price of this shipping method, in cents.",
-							Type:        schema.TypeInt,
-							Required:    true,
-						},
-						"free_over_amount_cents": {
-							Description: "Apply free shipping if the order amount is over this value, in cents.",
-							Type:        schema.TypeInt,
-							Optional:    true,
-						},
-						"use_subtotal": {
-							Description: "Send this attribute if you want to compare the free over amount with order's subtotal (excluding discounts, if any).",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-						},
-						"min_weight": {
-							Description: "The minimum weight for which this shipping method is available.",
-							Type:        schema.TypeFloat,
-							Optional:    true,
-						},
-						"max_weight": {
-							Description: "The maximum weight for which this shipping method is available.",
-							Type:        schema.TypeFloat,
-							Optional:    true,
-						},
-						"unit_of_weight": {
-							Description: "Can be one of 'gr', 'lb', or 'oz'",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"enabled": {
-							Description: "Whether the resource is enabled",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceShippingMethodAttributes(),
+			"relationships": resourceShippingMethodRelationships(),
+		},
+	})
+}
+
+func resourceShippingMethodAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The shipping method's name",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"scheme": {
+					Description: "The shipping method's scheme, one of 'flat', 'weight_tiered' or 'external'.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "flat",
+				},
+				"currency_code": {
+					Description: "The international 3-letter currency code as defined by the ISO " +
+						"4217 standard.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: currencyCodeValidation,
+				},
+				"external_prices_url": {
+					Description: "Required, if external scheme",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"price_amount_cents": {
+					Description: "The price of this shipping method, in cents.",
+					Type:        schema.TypeInt,
+					Required:    true,
+				},
+				"free_over_amount_cents": {
+					Description: "Apply free shipping if the order amount is over this value, in cents.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+				},
+				"use_subtotal": {
+					Description: "Send this attribute if you want to compare the free over amount with order's subtotal (excluding discounts, if any).",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"min_weight": {
+					Description: "The minimum weight for which this shipping method is available.",
+					Type:        schema.TypeFloat,
+					Optional:    true,
+				},
+				"max_weight": {
+					Description: "The maximum weight for which this shipping method is available.",
+					Type:        schema.TypeFloat,
+					Optional:    true,
+				},
+				"unit_of_weight": {
+					Description: "Can be one of 'gr', 'lb', or 'oz'",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"enabled": {
+					Description: "Whether the resource is enabled",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"market_id": {
-							Description: "The associated market id.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"shipping_zone_id": {
-							Description: "The shipping zone that is used to match the order shipping address.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"shipping_category_id": {
-							Description: "The shipping category for which this shipping method is available.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"stock_location_id": {
-							Description: "The stock location for which this shipping method is available.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"shipping_method_tier_ids": {
-							Description: "The associated shipping method tiers (meaningful when " +
-								"billing_scheme != 'flat').",
-							Type: schema.TypeList,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+		},
+	}
+}
+
+func resourceShippingMethodRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"market_id": {
+					Description: "The associated market id.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"shipping_zone_id": {
+					Description: "The shipping zone that is used to match the order shipping address.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"shipping_category_id": {
+					Description: "The shipping category for which this shipping method is available.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"stock_location_id": {
+					Description: "The stock location for which this shipping method is available.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"shipping_method_tier_ids": {
+					Description: "The associated shipping method tiers (meaningful when " +
+						"billing_scheme != 'flat').",
+					Type: schema.TypeList,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
 				},
 			},
 		},
@@ -163,9 +185,9 @@ func resourceShippingMethod() *schema.Resource {
 }
 
 func resourceShippingMethodReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.ShippingMethodsApi.GETShippingMethodsShippingMethodId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.ShippingMethodsApi.GETShippingMethodsShippingMethodId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -178,14 +200,30 @@ func resourceShippingMethodReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(shippingMethod.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	attributes := shippingMethod.GetAttributes()
+	err = setEnabled(d, attributes.GetDisabledAt())
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceShippingMethodCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceShippingMethodAttributes())
+	relationships := blockValues(d, "relationships", resourceShippingMethodRelationships())
 
 	shippingMethodCreate := commercelayer.ShippingMethodCreate{
 		Data: commercelayer.ShippingMethodCreateData{
@@ -203,7 +241,7 @@ func resourceShippingMethodCreateFunc(ctx context.Context, d *schema.ResourceDat
 				UnitOfWeight:        stringRef(attributes["unit_of_weight"]),
 				Reference:           stringRef(attributes["reference"]),
 				ReferenceOrigin:     stringRef(attributes["reference_origin"]),
-				Metadata:            keyValueRef(attributes["metadata"]),
+				Metadata:            metadataRef(attributes),
 			},
 			Relationships: &commercelayer.ShippingMethodCreateDataRelationships{},
 		},
@@ -273,52 +311,63 @@ func resourceShippingMethodCreateFunc(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(shippingMethod.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, shippingMethodType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceShippingMethodDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.ShippingMethodsApi.DELETEShippingMethodsShippingMethodId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, shippingMethodType)
 	return diag.FromErr(err)
 }
 
 func resourceShippingMethodUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceShippingMethodAttributes())
+	relationships := blockValues(d, "relationships", resourceShippingMethodRelationships())
 
 	var shippingMethodUpdate = commercelayer.ShippingMethodUpdate{
 		Data: commercelayer.ShippingMethodUpdateData{
 			Type: shippingMethodType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHShippingMethodsShippingMethodId200ResponseDataAttributes{
-				Name:                stringRef(attributes["name"]),
-				Scheme:              stringRef(attributes["scheme"]),
-				CurrencyCode:        stringRef(attributes["currency_code"]),
-				ExternalPricesUrl:   stringRef(attributes["external_prices_url"]),
-				PriceAmountCents:    intToInt32Ref(attributes["price_amount_cents"]),
-				FreeOverAmountCents: intToInt32Ref(attributes["free_over_amount_cents"]),
-				UseSubtotal:         boolRef(attributes["use_subtotal"]),
-				MinWeight:           float64ToFloat32Ref(attributes["min_weight"]),
-				MaxWeight:           float64ToFloat32Ref(attributes["max_weight"]),
-				UnitOfWeight:        stringRef(attributes["unit_of_weight"]),
-				Reference:           stringRef(attributes["reference"]),
-				ReferenceOrigin:     stringRef(attributes["reference_origin"]),
-				Metadata:            keyValueRef(attributes["metadata"]),
+				Name:                changedAttribute(d, "name", stringRef(attributes["name"])),
+				Scheme:              changedAttribute(d, "scheme", stringRef(attributes["scheme"])),
+				CurrencyCode:        changedAttribute(d, "currency_code", stringRef(attributes["currency_code"])),
+				ExternalPricesUrl:   changedAttribute(d, "external_prices_url", stringRef(attributes["external_prices_url"])),
+				PriceAmountCents:    changedAttribute(d, "price_amount_cents", configuredInt32Ref(d, "price_amount_cents")),
+				FreeOverAmountCents: changedAttribute(d, "free_over_amount_cents", configuredInt32Ref(d, "free_over_amount_cents")),
+				UseSubtotal:         changedAttribute(d, "use_subtotal", boolRef(attributes["use_subtotal"])),
+				MinWeight:           changedAttribute(d, "min_weight", configuredFloat32Ref(d, "min_weight")),
+				MaxWeight:           changedAttribute(d, "max_weight", configuredFloat32Ref(d, "max_weight")),
+				UnitOfWeight:        changedAttribute(d, "unit_of_weight", stringRef(attributes["unit_of_weight"])),
+				Reference:           changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:     changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 			Relationships: &commercelayer.ShippingMethodCreateDataRelationships{},
 		},
 	}
 
-	if !attributes["enabled"].(bool) {
-		shippingMethodUpdate.Data.Attributes.Disable = true
-	} else {
-		shippingMethodUpdate.Data.Attributes.Enable = true
+	if hasAttributeChange(d, "enabled") {
+		if !attributes["enabled"].(bool) {
+			shippingMethodUpdate.Data.Attributes.Disable = true
+		} else {
+			shippingMethodUpdate.Data.Attributes.Enable = true
+		}
 	}
 
 	marketId := stringRef(relationships["market_id"])
-	if marketId != nil {
+	if marketId != nil && hasRelationshipChange(d, "market_id") {
 		shippingMethodUpdate.Data.Relationships.Market = &commercelayer.BillingInfoValidationRuleCreateDataRelationshipsMarket{
 			Data: commercelayer.AvalaraAccountDataRelationshipsMarketsData{
 				Type: stringRef(marketType),
@@ -327,7 +376,7 @@ func resourceShippingMethodUpdateFunc(ctx context.Context, d *schema.ResourceDat
 	}
 
 	shippingZoneId := stringRef(relationships["shipping_zone_id"])
-	if shippingZoneId != nil {
+	if shippingZoneId != nil && hasRelationshipChange(d, "shipping_zone_id") {
 		shippingMethodUpdate.Data.Relationships.ShippingZone = &commercelayer.ShippingMethodCreateDataRelationshipsShippingZone{
 			Data: commercelayer.ShippingMethodDataRelationshipsShippingZoneData{
 				Type: stringRef(shippingZoneType),
@@ -336,7 +385,7 @@ func resourceShippingMethodUpdateFunc(ctx context.Context, d *schema.ResourceDat
 	}
 
 	shippingCategoryId := stringRef(relationships["shipping_category_id"])
-	if shippingCategoryId != nil {
+	if shippingCategoryId != nil && hasRelationshipChange(d, "shipping_category_id") {
 		shippingMethodUpdate.Data.Relationships.ShippingCategory = &commercelayer.ShipmentCreateDataRelationshipsShippingCategory{
 			Data: commercelayer.ShipmentDataRelationshipsShippingCategoryData{
 				Type: stringRef(shippingCategoryType),
@@ -345,7 +394,7 @@ func resourceShippingMethodUpdateFunc(ctx context.Context, d *schema.ResourceDat
 	}
 
 	stockLocationId := stringRef(relationships["stock_location_id"])
-	if stockLocationId != nil {
+	if stockLocationId != nil && hasRelationshipChange(d, "stock_location_id") {
 		shippingMethodUpdate.Data.Relationships.StockLocation = &commercelayer.DeliveryLeadTimeCreateDataRelationshipsStockLocation{
 			Data: commercelayer.DeliveryLeadTimeDataRelationshipsStockLocationData{
 				Type: stringRef(stockLocationType),
@@ -363,7 +412,28 @@ func resourceShippingMethodUpdateFunc(ctx context.Context, d *schema.ResourceDat
 	//		}}
 	//}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, shippingMethodType)
+		if err != nil {
+			return diagErr(err)
+		}
+		shippingMethodUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.ShippingMethodsApi.PATCHShippingMethodsShippingMethodId(ctx, d.Id()).ShippingMethodUpdate(shippingMethodUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	err = clearRemovedRelationships(ctx, c, d, shippingMethodType, map[string]string{
+		"market_id":            "market",
+		"shipping_zone_id":     "shipping_zone",
+		"shipping_category_id": "shipping_category",
+		"stock_location_id":    "stock_location",
+	})
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
 }
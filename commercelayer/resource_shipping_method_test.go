@@ -1,23 +1,16 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 )
 
 func testAccCheckShippingMethodDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_shipping_method" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.ShippingMethodsApi.GETShippingMethodsShippingMethodId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -34,41 +27,41 @@ func (s *AcceptanceSuite) TestAccShippingMethod_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckShippingMethodDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckShippingMethodDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccShippingMethodCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", shippingMethodType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Test Shipping Method"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.scheme", "flat"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.currency_code", "EUR"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.price_amount_cents", "1000"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.free_over_amount_cents", "10000"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.min_weight", "0.5"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.max_weight", "10"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.unit_of_weight", "kg"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.use_subtotal", "false"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Test Shipping Method"),
+					resource.TestCheckResourceAttr(resourceName, "scheme", "flat"),
+					resource.TestCheckResourceAttr(resourceName, "currency_code", "EUR"),
+					resource.TestCheckResourceAttr(resourceName, "price_amount_cents", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "free_over_amount_cents", "10000"),
+					resource.TestCheckResourceAttr(resourceName, "min_weight", "0.5"),
+					resource.TestCheckResourceAttr(resourceName, "max_weight", "10"),
+					resource.TestCheckResourceAttr(resourceName, "unit_of_weight", "kg"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "use_subtotal", "false"),
 				),
 			},
 			{
 				Config: testAccShippingMethodUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Test Shipping Method Updated"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.scheme", "external"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.currency_code", "CHF"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.external_prices_url", "https://api.commercelayer.io/v1/prices"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.price_amount_cents", "1"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.free_over_amount_cents", "1"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.min_weight", "1"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.max_weight", "20"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.unit_of_weight", "oz"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.enabled", "false"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.use_subtotal", "true"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Test Shipping Method Updated"),
+					resource.TestCheckResourceAttr(resourceName, "scheme", "external"),
+					resource.TestCheckResourceAttr(resourceName, "currency_code", "CHF"),
+					resource.TestCheckResourceAttr(resourceName, "external_prices_url", "https://api.commercelayer.io/v1/prices"),
+					resource.TestCheckResourceAttr(resourceName, "price_amount_cents", "1"),
+					resource.TestCheckResourceAttr(resourceName, "free_over_amount_cents", "1"),
+					resource.TestCheckResourceAttr(resourceName, "min_weight", "1"),
+					resource.TestCheckResourceAttr(resourceName, "max_weight", "20"),
+					resource.TestCheckResourceAttr(resourceName, "unit_of_weight", "oz"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "use_subtotal", "true"),
 				),
 			},
 		},
@@ -78,7 +71,6 @@ func (s *AcceptanceSuite) TestAccShippingMethod_basic() {
 func testAccShippingMethodCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_shipping_method" "incentro_shipping_method" {
-		  attributes {
 			name                   = "Incentro Test Shipping Method"
 			scheme                 = "flat"
 			currency_code          = "EUR"
@@ -91,7 +83,6 @@ func testAccShippingMethodCreate(testName string) string {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -99,7 +90,6 @@ func testAccShippingMethodCreate(testName string) string {
 func testAccShippingMethodUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_shipping_method" "incentro_shipping_method" {
-		  attributes {
 			name                   = "Incentro Test Shipping Method Updated"
 			scheme                 = "external"
 			currency_code          = "CHF"
@@ -115,7 +105,6 @@ func testAccShippingMethodUpdate(testName string) string {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
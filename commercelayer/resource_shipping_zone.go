@@ -2,13 +2,15 @@ package commercelayer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func resourceShippingZone() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Shipping zones determine the available shipping methods for a given shipping address. The " +
 			"match is evaluated against a set of regular expressions on the address country, state or zip code.",
 		ReadContext:   resourceShippingZoneReadFunc,
@@ -18,6 +20,7 @@ func resourceShippingZone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The shipping zone unique identifier",
@@ -29,74 +32,132 @@ func resourceShippingZone() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
+			"attributes": resourceShippingZoneAttributes(),
+			"expect_match": {
+				Description: "Sample addresses the shipping zone must match. They are checked during plan and " +
+					"never sent to Commerce Layer.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     resourceShippingZoneSampleAddress(),
+			},
+			"expect_no_match": {
+				Description: "Sample addresses the shipping zone must not match. They are checked during plan and " +
+					"never sent to Commerce Layer.",
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     resourceShippingZoneSampleAddress(),
+			},
+		},
+		CustomizeDiff: resourceShippingZoneCustomizeDiff,
+	})
+}
+
+func resourceShippingZoneSampleAddress() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"country_code": {
+				Description: "The country code of the address.",
+				Type:        schema.TypeString,
 				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The shipping zone's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"country_code_regex": {
-							Description: "The regex that will be evaluated to match the shipping address country code.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"not_country_code_regex": {
-							Description: "The regex that will be evaluated as negative match for the shipping " +
-								"address country code.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"state_code_regex": {
-							Description: "The regex that will be evaluated to match the shipping address state code.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"not_state_code_regex": {
-							Description: "The regex that will be evaluated as negative match for the shipping " +
-								"address state code.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"zip_code_regex": {
-							Description: "The regex that will be evaluated to match the shipping address zip code.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"not_zip_code_regex": {
-							Description: "The regex that will be evaluated as negative match for the shipping zip " +
-								"country code.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			},
+			"state_code": {
+				Description: "The state code of the address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"zip_code": {
+				Description: "The zip code of the address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceShippingZoneAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The shipping zone's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"country_code_regex": {
+					Description:      "The regex that will be evaluated to match the shipping address country code.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: shippingZoneRegexValidation,
+				},
+				"not_country_code_regex": {
+					Description: "The regex that will be evaluated as negative match for the shipping " +
+						"address country code.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: shippingZoneRegexValidation,
+				},
+				"state_code_regex": {
+					Description:      "The regex that will be evaluated to match the shipping address state code.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: shippingZoneRegexValidation,
+				},
+				"not_state_code_regex": {
+					Description: "The regex that will be evaluated as negative match for the shipping " +
+						"address state code.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: shippingZoneRegexValidation,
+				},
+				"zip_code_regex": {
+					Description:      "The regex that will be evaluated to match the shipping address zip code.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: shippingZoneRegexValidation,
+				},
+				"not_zip_code_regex": {
+					Description: "The regex that will be evaluated as negative match for the shipping zip " +
+						"country code.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: shippingZoneRegexValidation,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -104,9 +165,9 @@ func resourceShippingZone() *schema.Resource {
 }
 
 func resourceShippingZoneReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.ShippingZonesApi.GETShippingZonesShippingZoneId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.ShippingZonesApi.GETShippingZonesShippingZoneId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -119,13 +180,23 @@ func resourceShippingZoneReadFunc(ctx context.Context, d *schema.ResourceData, i
 
 	d.SetId(shippingZone.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceShippingZoneCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceShippingZoneAttributes())
 
 	shippingZoneCreate := commercelayer.ShippingZoneCreate{
 		Data: commercelayer.ShippingZoneCreateData{
@@ -140,7 +211,7 @@ func resourceShippingZoneCreateFunc(ctx context.Context, d *schema.ResourceData,
 				NotZipCodeRegex:     stringRef(attributes["not_zip_code_regex"]),
 				Reference:           stringRef(attributes["reference"]),
 				ReferenceOrigin:     stringRef(attributes["reference_origin"]),
-				Metadata:            keyValueRef(attributes["metadata"]),
+				Metadata:            metadataRef(attributes),
 			},
 		},
 	}
@@ -157,40 +228,99 @@ func resourceShippingZoneCreateFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(shippingZone.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, shippingZoneType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceShippingZoneDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.ShippingZonesApi.DELETEShippingZonesShippingZoneId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, shippingZoneType)
 	return diag.FromErr(err)
 }
 
 func resourceShippingZoneUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceShippingZoneAttributes())
 
 	var shippingZoneUpdate = commercelayer.ShippingZoneUpdate{
 		Data: commercelayer.ShippingZoneUpdateData{
 			Type: shippingZoneType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHShippingZonesShippingZoneId200ResponseDataAttributes{
-				Name:                stringRef(attributes["name"]),
-				CountryCodeRegex:    stringRef(attributes["country_code_regex"]),
-				NotCountryCodeRegex: stringRef(attributes["not_country_code_regex"]),
-				StateCodeRegex:      stringRef(attributes["state_code_regex"]),
-				NotStateCodeRegex:   stringRef(attributes["not_state_code_regex"]),
-				ZipCodeRegex:        stringRef(attributes["zip_code_regex"]),
-				NotZipCodeRegex:     stringRef(attributes["not_zip_code_regex"]),
-				Reference:           stringRef(attributes["reference"]),
-				ReferenceOrigin:     stringRef(attributes["reference_origin"]),
-				Metadata:            keyValueRef(attributes["metadata"]),
+				Name:                changedAttribute(d, "name", stringRef(attributes["name"])),
+				CountryCodeRegex:    changedAttribute(d, "country_code_regex", stringRef(attributes["country_code_regex"])),
+				NotCountryCodeRegex: changedAttribute(d, "not_country_code_regex", stringRef(attributes["not_country_code_regex"])),
+				StateCodeRegex:      changedAttribute(d, "state_code_regex", stringRef(attributes["state_code_regex"])),
+				NotStateCodeRegex:   changedAttribute(d, "not_state_code_regex", stringRef(attributes["not_state_code_regex"])),
+				ZipCodeRegex:        changedAttribute(d, "zip_code_regex", stringRef(attributes["zip_code_regex"])),
+				NotZipCodeRegex:     changedAttribute(d, "not_zip_code_regex", stringRef(attributes["not_zip_code_regex"])),
+				Reference:           changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:     changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, shippingZoneType)
+		if err != nil {
+			return diagErr(err)
+		}
+		shippingZoneUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.ShippingZonesApi.PATCHShippingZonesShippingZoneId(ctx, d.Id()).ShippingZoneUpdate(shippingZoneUpdate).Execute()
 
 	return diag.FromErr(err)
 }
+
+// resourceShippingZoneCustomizeDiff checks the sample addresses of expect_match and expect_no_match against the
+// regexes of the shipping zone. The check is skipped while a regex or sample address is not known yet.
+func resourceShippingZoneCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	regexes := map[string]string{}
+	for _, key := range shippingZoneRegexKeys {
+		if !d.NewValueKnown(attributeKey(d, key)) {
+			return nil
+		}
+		regexes[key] = d.Get(attributeKey(d, key)).(string)
+	}
+
+	var errs []error
+	for _, expectation := range []struct {
+		key     string
+		matches bool
+		message string
+	}{
+		{"expect_match", true, "does not match"},
+		{"expect_no_match", false, "matches"},
+	} {
+		if !d.NewValueKnown(expectation.key) {
+			continue
+		}
+
+		for _, sample := range d.Get(expectation.key).([]interface{}) {
+			address := sample.(map[string]any)
+			matches, err := shippingZoneMatches(regexes, address["country_code"].(string),
+				address["state_code"].(string), address["zip_code"].(string))
+			if err != nil {
+				return err
+			}
+			if matches != expectation.matches {
+				errs = append(errs, fmt.Errorf("%s: the shipping zone %s country %q, state %q, zip %q",
+					expectation.key, expectation.message, address["country_code"], address["state_code"],
+					address["zip_code"]))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
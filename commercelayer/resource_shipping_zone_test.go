@@ -1,23 +1,17 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
+	"regexp"
 )
 
 func testAccCheckShippingZoneDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_shipping_zone" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.ShippingZonesApi.GETShippingZonesShippingZoneId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -34,34 +28,66 @@ func (s *AcceptanceSuite) TestAccShippingZone_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckShippingZoneDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckShippingZoneDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccShippingZoneCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", shippingZoneType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Shipping Zone"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.country_code_regex", ".*"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.not_country_code_regex", "[^i*&2@]"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.state_code_regex", "^dog"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.not_state_code_regex", "//[^\r\n]*[\r\n]"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.zip_code_regex", "[a-zA-Z]{2,4}"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.not_zip_code_regex", ".+"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Shipping Zone"),
+					resource.TestCheckResourceAttr(resourceName, "country_code_regex", ".*"),
+					resource.TestCheckResourceAttr(resourceName, "not_country_code_regex", "[^i*&2@]"),
+					resource.TestCheckResourceAttr(resourceName, "state_code_regex", "^dog"),
+					resource.TestCheckResourceAttr(resourceName, "not_state_code_regex", "//[^\r\n]*[\r\n]"),
+					resource.TestCheckResourceAttr(resourceName, "zip_code_regex", "[a-zA-Z]{2,4}"),
+					resource.TestCheckResourceAttr(resourceName, "not_zip_code_regex", ".+"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccShippingZoneUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Shipping Zone Updated"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.country_code_regex", ".+"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.not_country_code_regex", "[^i*&2@]G"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.state_code_regex", "^cat"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.not_state_code_regex", "//[^\r\n]"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.zip_code_regex", "[a-z]{1,2}"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.not_zip_code_regex", ".*"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Shipping Zone Updated"),
+					resource.TestCheckResourceAttr(resourceName, "country_code_regex", ".+"),
+					resource.TestCheckResourceAttr(resourceName, "not_country_code_regex", "[^i*&2@]G"),
+					resource.TestCheckResourceAttr(resourceName, "state_code_regex", "^cat"),
+					resource.TestCheckResourceAttr(resourceName, "not_state_code_regex", "//[^\r\n]"),
+					resource.TestCheckResourceAttr(resourceName, "zip_code_regex", "[a-z]{1,2}"),
+					resource.TestCheckResourceAttr(resourceName, "not_zip_code_regex", ".*"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+				),
+			},
+		},
+	})
+}
+
+func (s *AcceptanceSuite) TestAccShippingZone_expectations() {
+	resourceName := "commercelayer_shipping_zone.incentro_shipping_zone"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckShippingZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccShippingZoneExpectations(resourceName, "(NL", "NL", "DE"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid regex provided"),
+			},
+			{
+				Config:      testAccShippingZoneExpectations(resourceName, "^NL$", "DE", "NL"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expect_match: the shipping zone does not match country \"DE\""),
+			},
+			{
+				Config: testAccShippingZoneExpectations(resourceName, "^NL$", "NL", "DE"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "country_code_regex", "^NL$"),
+					resource.TestCheckResourceAttr(resourceName, "expect_match.0.country_code", "NL"),
+					resource.TestCheckResourceAttr(resourceName, "expect_no_match.0.country_code", "DE"),
 				),
 			},
 		},
@@ -71,7 +97,6 @@ func (s *AcceptanceSuite) TestAccShippingZone_basic() {
 func testAccShippingZoneCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_shipping_zone" "incentro_shipping_zone" {
-		  attributes {
 			name                   = "Incentro Shipping Zone"
 			country_code_regex     = ".*"
 			not_country_code_regex = "[^i*&2@]"
@@ -83,7 +108,6 @@ func testAccShippingZoneCreate(testName string) string {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -91,7 +115,6 @@ func testAccShippingZoneCreate(testName string) string {
 func testAccShippingZoneUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_shipping_zone" "incentro_shipping_zone" {
-		  attributes {
 			name                   = "Incentro Shipping Zone Updated"
 			country_code_regex     = ".+"
 			not_country_code_regex = "[^i*&2@]G"
@@ -103,7 +126,28 @@ func testAccShippingZoneUpdate(testName string) string {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
+
+func testAccShippingZoneExpectations(testName string, countryCodeRegex string, match string, noMatch string) string {
+	return hclTemplate(`
+		resource "commercelayer_shipping_zone" "incentro_shipping_zone" {
+			name               = "Incentro Shipping Zone"
+			country_code_regex = "{{.countryCodeRegex}}"
+			metadata           = {
+		 	  testName: "{{.testName}}"
+			}
+
+			expect_match {
+			  country_code = "{{.match}}"
+			  zip_code     = "3044 BC"
+			}
+
+			expect_no_match {
+			  country_code = "{{.noMatch}}"
+			}
+		}
+	`, map[string]any{"testName": testName, "countryCodeRegex": countryCodeRegex, "match": match,
+		"noMatch": noMatch})
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceStockLocation() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Shipping zones determine the available shipping methods for a given shipping address. The " +
 			"match is evaluated against a set of regular expressions on the address country, state or zip code.",
 		ReadContext:   resourceStockLocationReadFunc,
@@ -18,6 +18,7 @@ func resourceStockLocation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The stock location unique identifier",
@@ -29,69 +30,88 @@ func resourceStockLocation() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The stock location's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"label_format": {
-							Description: "The shipping label format for this stock location. Can be one of 'PDF'" +
-								", 'ZPL', 'EPL2', or 'PNG'",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"suppress_etd": {
-							Description: "Flag it if you want to skip the electronic invoice creation when " +
-								"generating the customs info for this stock location shipments.",
-							Type:     schema.TypeBool,
-							Optional: true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes":    resourceStockLocationAttributes(),
+			"relationships": resourceStockLocationRelationships(),
+		},
+	})
+}
+
+func resourceStockLocationAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The stock location's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"label_format": {
+					Description: "The shipping label format for this stock location. Can be one of 'PDF'" +
+						", 'ZPL', 'EPL2', or 'PNG'",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"suppress_etd": {
+					Description: "Flag it if you want to skip the electronic invoice creation when " +
+						"generating the customs info for this stock location shipments.",
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
-			"relationships": {
-				Description: "Resource relationships",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"address_id": {
-							Description: "The associated address id.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-					},
+		},
+	}
+}
+
+func resourceStockLocationRelationships() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource relationships",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"address_id": {
+					Description: "The associated address id.",
+					Type:        schema.TypeString,
+					Required:    true,
 				},
 			},
 		},
@@ -99,9 +119,9 @@ func resourceStockLocation() *schema.Resource {
 }
 
 func resourceStockLocationReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.StockLocationsApi.GETStockLocationsStockLocationId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.StockLocationsApi.GETStockLocationsStockLocationId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -114,14 +134,24 @@ func resourceStockLocationReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(stockLocation.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceStockLocationCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceStockLocationAttributes())
+	relationships := blockValues(d, "relationships", resourceStockLocationRelationships())
 
 	stockLocationCreate := commercelayer.StockLocationCreate{
 		Data: commercelayer.StockLocationCreateData{
@@ -132,7 +162,7 @@ func resourceStockLocationCreateFunc(ctx context.Context, d *schema.ResourceData
 				SuppressEtd:     boolRef(attributes["suppress_etd"]),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 			Relationships: &commercelayer.MerchantCreateDataRelationships{
 				Address: commercelayer.CustomerAddressCreateDataRelationshipsAddress{
@@ -157,44 +187,63 @@ func resourceStockLocationCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(stockLocation.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, stockLocationType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceStockLocationDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.StockLocationsApi.DELETEStockLocationsStockLocationId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, stockLocationType)
 	return diag.FromErr(err)
 }
 
 func resourceStockLocationUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
-	relationships := nestedMap(d.Get("relationships"))
+	attributes := blockValues(d, "attributes", resourceStockLocationAttributes())
+	relationships := blockValues(d, "relationships", resourceStockLocationRelationships())
 
 	var stockLocationUpdate = commercelayer.StockLocationUpdate{
 		Data: commercelayer.StockLocationUpdateData{
 			Type: stockLocationType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHStockLocationsStockLocationId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				LabelFormat:     stringRef(attributes["label_format"]),
-				SuppressEtd:     boolRef(attributes["suppress_etd"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
-			},
-			Relationships: &commercelayer.MerchantUpdateDataRelationships{
-				Address: &commercelayer.CustomerAddressCreateDataRelationshipsAddress{
-					Data: commercelayer.BingGeocoderDataRelationshipsAddressesData{
-						Type: stringRef(addressType),
-						Id:   stringRef(relationships["address_id"]),
-					},
-				},
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				LabelFormat:     changedAttribute(d, "label_format", stringRef(attributes["label_format"])),
+				SuppressEtd:     changedAttribute(d, "suppress_etd", boolRef(attributes["suppress_etd"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
+			Relationships: &commercelayer.MerchantUpdateDataRelationships{},
 		},
 	}
 
+	if hasRelationshipChange(d, "address_id") {
+		stockLocationUpdate.Data.Relationships.Address = &commercelayer.CustomerAddressCreateDataRelationshipsAddress{
+			Data: commercelayer.BingGeocoderDataRelationshipsAddressesData{
+				Type: stringRef(addressType),
+				Id:   stringRef(relationships["address_id"]),
+			},
+		}
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, stockLocationType)
+		if err != nil {
+			return diagErr(err)
+		}
+		stockLocationUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.StockLocationsApi.PATCHStockLocationsStockLocationId(ctx, d.Id()).StockLocationUpdate(stockLocationUpdate).Execute()
 
 	return diag.FromErr(err)
@@ -1,24 +1,17 @@
 package commercelayer
 
 import (
-	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
-	"net/http"
 	"strings"
 )
 
 func testAccCheckStockLocationDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_stock_location" {
-			err := retryRemoval(10, func() (*http.Response, error) {
-				_, resp, err := client.StockLocationsApi.GETStockLocationsStockLocationId(context.Background(), rs.Primary.ID).
-					Execute()
-				return resp, err
-			})
+			err := testAccWaitForRemoval(client, rs)
 			if err != nil {
 				return err
 			}
@@ -35,26 +28,26 @@ func (s *AcceptanceSuite) TestAccStockLocation_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckStockLocationDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckStockLocationDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: strings.Join([]string{testAccAddressCreate(resourceName), testAccStockLocationCreate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", stockLocationType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Stock Location"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.label_format", "PNG"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.suppress_etd", "true"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Stock Location"),
+					resource.TestCheckResourceAttr(resourceName, "label_format", "PNG"),
+					resource.TestCheckResourceAttr(resourceName, "suppress_etd", "true"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: strings.Join([]string{testAccAddressCreate(resourceName), testAccStockLocationUpdate(resourceName)}, "\n"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Stock Location Updated"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.label_format", "PDF"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.suppress_etd", "false"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Stock Location Updated"),
+					resource.TestCheckResourceAttr(resourceName, "label_format", "PDF"),
+					resource.TestCheckResourceAttr(resourceName, "suppress_etd", "false"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -64,7 +57,6 @@ func (s *AcceptanceSuite) TestAccStockLocation_basic() {
 func testAccStockLocationCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_stock_location" "incentro_stock_location" {
-		  attributes {
 			name         = "Incentro Stock Location"
 			label_format = "PNG"
 			suppress_etd = true
@@ -72,11 +64,8 @@ func testAccStockLocationCreate(testName string) string {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		
-		  relationships {
 			address_id = commercelayer_address.incentro_address.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -84,7 +73,6 @@ func testAccStockLocationCreate(testName string) string {
 func testAccStockLocationUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_stock_location" "incentro_stock_location" {
-		  attributes {
 			name         = "Incentro Stock Location Updated"
 			label_format = "PDF"
 			suppress_etd = false
@@ -92,11 +80,8 @@ func testAccStockLocationUpdate(testName string) string {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		
-		  relationships {
 			address_id = commercelayer_address.incentro_address.id
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -9,7 +9,7 @@ import (
 )
 
 func resourceStripeGateway() *schema.Resource {
-	return &schema.Resource{
+	return protectedResource(flattenedResource(&schema.Resource{
 		Description: "Configuring a Stripe payment gateway for a market lets you safely process payments through Stripe. " +
 			"The Stripe gateway is compliant with the PSD2 European regulation so that you can implement a payment flow " +
 			"that supports SCA and 3DS2 by using the Stripe's official JS SDK and libraries." +
@@ -23,6 +23,7 @@ func resourceStripeGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The stripe payment unique identifier",
@@ -34,51 +35,107 @@ func resourceStripeGateway() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The payment gateway's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"login": {
-							Description: "The gateway login.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"publishable_key": {
-							Description: "The gateway publishable API key.",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"webhook_endpoint_id": {
+				Description: "The gateway webhook endpoint ID, generated automatically.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"webhook_endpoint_secret": {
+				Description: "The gateway webhook endpoint secret, generated automatically.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"webhook_endpoint_url": {
+				Description: "The gateway webhook URL, generated automatically.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes": resourceStripeGatewayAttributes(),
+		},
+	}))
+}
+
+func resourceStripeGatewayAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The payment gateway's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"login": {
+					Description: "The gateway login.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"publishable_key": {
+					Description: "The gateway publishable API key.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"connected_account": {
+					Description: "The account (if any) for which the funds of the PaymentIntent are intended.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"auto_payments": {
+					Description: "Indicates if the gateway will accept payment methods enabled in the Stripe dashboard.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"force_payments": {
+					Description: "Indicates if the gateway will use the payment methods enabled in the Stripe " +
+						"dashboard, ignoring the ones sent by the client.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"enabled": {
+					Description: "Indicates if the payment gateway is enabled, disabled gateways can't be " +
+						"used by payment methods.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -86,9 +143,9 @@ func resourceStripeGateway() *schema.Resource {
 }
 
 func resourceStripeGatewayReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.StripeGatewaysApi.GETStripeGatewaysStripeGatewayId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.StripeGatewaysApi.GETStripeGatewaysStripeGatewayId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -101,24 +158,57 @@ func resourceStripeGatewayReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(stripeGateway.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	disabledAt, err := disabledAtFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setEnabled(d, disabledAt)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	attributes := stripeGateway.GetAttributes()
+	for key, value := range map[string]interface{}{
+		"webhook_endpoint_id":     attributes.GetWebhookEndpointId(),
+		"webhook_endpoint_secret": attributes.GetWebhookEndpointSecret(),
+		"webhook_endpoint_url":    attributes.GetWebhookEndpointUrl(),
+	} {
+		if err := d.Set(key, value); err != nil {
+			return diagErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceStripeGatewayCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceStripeGatewayAttributes())
 
 	stripeGatewayCreate := commercelayer.StripeGatewayCreate{
 		Data: commercelayer.StripeGatewayCreateData{
 			Type: stripeGatewaysType,
 			Attributes: commercelayer.POSTStripeGateways201ResponseDataAttributes{
-				Name:            attributes["name"].(string),
-				Login:           attributes["login"].(string),
-				PublishableKey:  stringRef(attributes["publishable_key"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:             attributes["name"].(string),
+				Login:            attributes["login"].(string),
+				PublishableKey:   stringRef(attributes["publishable_key"]),
+				ConnectedAccount: stringRef(attributes["connected_account"]),
+				AutoPayments:     boolRef(attributes["auto_payments"]),
+				Reference:        stringRef(attributes["reference"]),
+				ReferenceOrigin:  stringRef(attributes["reference_origin"]),
+				Metadata:         metadataRef(attributes),
 			},
 		},
 	}
@@ -135,35 +225,90 @@ func resourceStripeGatewayCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(stripeGateway.Data.GetId().(string))
 
-	return nil
+	err = waitForCreated(ctx, c, d, stripeGatewaysType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if !attributes["enabled"].(bool) {
+		err = patchEnabled(ctx, c, stripeGatewaysType, d.Id(), false)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	if attributes["force_payments"].(bool) {
+		err = patchStripeGatewayForcePayments(ctx, c, d.Id(), true)
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return resourceStripeGatewayReadFunc(ctx, d, i)
 }
 
 func resourceStripeGatewayDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.StripeGatewaysApi.DELETEStripeGatewaysStripeGatewayId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, stripeGatewaysType)
 	return diag.FromErr(err)
 }
 
 func resourceStripeGatewayUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceStripeGatewayAttributes())
 
 	var stripeGatewayUpdate = commercelayer.StripeGatewayUpdate{
 		Data: commercelayer.StripeGatewayUpdateData{
 			Type: stripeGatewaysType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHStripeGatewaysStripeGatewayId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"].(string)),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:             changedAttribute(d, "name", stringRef(attributes["name"].(string))),
+				ConnectedAccount: changedAttribute(d, "connected_account", stringRef(attributes["connected_account"])),
+				AutoPayments:     changedAttribute(d, "auto_payments", boolRef(attributes["auto_payments"])),
+				Reference:        changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:  changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, stripeGatewaysType)
+		if err != nil {
+			return diagErr(err)
+		}
+		stripeGatewayUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.StripeGatewaysApi.PATCHStripeGatewaysStripeGatewayId(ctx, d.Id()).
 		StripeGatewayUpdate(stripeGatewayUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
 
-	return diag.FromErr(err)
+	if hasAttributeChange(d, "enabled") {
+		err = patchEnabled(ctx, c, stripeGatewaysType, d.Id(), attributes["enabled"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	if hasAttributeChange(d, "force_payments") {
+		err = patchStripeGatewayForcePayments(ctx, c, d.Id(), attributes["force_payments"].(bool))
+		if err != nil {
+			return diagErr(err)
+		}
+	}
+
+	return resourceStripeGatewayReadFunc(ctx, d, i)
+}
+
+// patchStripeGatewayForcePayments sets the force_payments attribute, which is not part of the generated SDK models.
+func patchStripeGatewayForcePayments(ctx context.Context, c *apiClient, id string, forcePayments bool) error {
+	return patchResource(ctx, c, stripeGatewaysType, id, map[string]any{"force_payments": forcePayments}, nil)
 }
@@ -6,11 +6,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckStripeGatewayDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_stripe_gateway" {
@@ -37,22 +36,27 @@ func (s *AcceptanceSuite) TestAccStripeGateway_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckStripeGatewayDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckStripeGatewayDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccStripeGatewayCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", stripeGatewaysType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Stripe Gateway"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Stripe Gateway"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "auto_payments", "false"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_endpoint_url",
+						"https://core.commercelayer.io/webhook_callbacks/stripe_gateways/LjBAQsaezv/eu-west-1"),
 				),
 			},
 			{
 				Config: testAccStripeGatewayUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Stripe Gateway Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Stripe Gateway Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "auto_payments", "true"),
+					resource.TestCheckResourceAttr(resourceName, "connected_account", "acct_1032D82eZvKYlo2C"),
 				),
 			},
 		},
@@ -62,7 +66,6 @@ func (s *AcceptanceSuite) TestAccStripeGateway_basic() {
 func testAccStripeGatewayCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_stripe_gateway" "incentro_stripe_gateway" {
-           attributes {
 			name        	= "Incentro Stripe Gateway"
 			login       	= "xxxx-yyyy-zzzz"
 			publishable_key = "aaaa-bbbb-cccc"
@@ -71,7 +74,6 @@ func testAccStripeGatewayCreate(testName string) string {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -79,16 +81,16 @@ func testAccStripeGatewayCreate(testName string) string {
 func testAccStripeGatewayUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_stripe_gateway" "incentro_stripe_gateway" {
-           attributes {
-			name        	= "Incentro Stripe Gateway Changed"
-			login       	= "xxxx-yyyy-zzzz"
-			publishable_key = "aaaa-bbbb-cccc"
+			name        	  = "Incentro Stripe Gateway Changed"
+			login       	  = "xxxx-yyyy-zzzz"
+			publishable_key   = "aaaa-bbbb-cccc"
+			connected_account = "acct_1032D82eZvKYlo2C"
+			auto_payments     = true
 
 			metadata = {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
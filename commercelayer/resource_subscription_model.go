@@ -0,0 +1,239 @@
+package commercelayer
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+func resourceSubscriptionModel() *schema.Resource {
+	return flattenedResource(&schema.Resource{
+		Description: "A subscription model defines the frequencies and the strategy used to generate order " +
+			"subscriptions for a market. When a market is associated with a subscription model, orders " +
+			"placed in that market can generate recurring order subscriptions.",
+		ReadContext:   resourceSubscriptionModelReadFunc,
+		CreateContext: resourceSubscriptionModelCreateFunc,
+		UpdateContext: resourceSubscriptionModelUpdateFunc,
+		DeleteContext: resourceSubscriptionModelDeleteFunc,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The subscription model unique identifier",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "The resource type",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"attributes": resourceSubscriptionModelAttributes(),
+		},
+	})
+}
+
+func resourceSubscriptionModelAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The subscription model's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"strategy": {
+					Description: "The subscription model's strategy used to generate order subscriptions: " +
+						"one between 'by_frequency' (default) and 'by_line_items'.",
+					Type:             schema.TypeString,
+					Default:          "by_frequency",
+					Optional:         true,
+					ValidateDiagFunc: subscriptionModelStrategyValidation,
+				},
+				"frequencies": {
+					Description: "The frequencies available for this subscription model. Supported ones are " +
+						"'hourly', 'daily', 'weekly', 'monthly', 'two-month', 'three-month', 'four-month', " +
+						"'six-month', 'yearly', or a custom crontab expression (min unit is hour).",
+					Type:     schema.TypeList,
+					MinItems: 1,
+					Required: true,
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: subscriptionModelFrequencyValidation,
+					},
+				},
+				"auto_activate": {
+					Description: "Indicates if the created subscriptions will be activated considering the " +
+						"placed source order as its first run.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"auto_cancel": {
+					Description: "Indicates if the created subscriptions will be cancelled in case the " +
+						"source order is cancelled.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
+				},
+			},
+		},
+	}
+}
+
+func resourceSubscriptionModelReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	resp, httpResp, err := c.SubscriptionModelsApi.GETSubscriptionModelsSubscriptionModelId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	subscriptionModel, ok := resp.GetDataOk()
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(subscriptionModel.GetId().(string))
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceSubscriptionModelCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceSubscriptionModelAttributes())
+
+	subscriptionModelCreate := commercelayer.SubscriptionModelCreate{
+		Data: commercelayer.SubscriptionModelCreateData{
+			Type: subscriptionModelsType,
+			Attributes: commercelayer.POSTSubscriptionModels201ResponseDataAttributes{
+				Name:            attributes["name"].(string),
+				Strategy:        stringRef(attributes["strategy"]),
+				Frequencies:     stringSliceValueRef(attributes["frequencies"]),
+				AutoActivate:    boolRef(attributes["auto_activate"]),
+				AutoCancel:      boolRef(attributes["auto_cancel"]),
+				Reference:       stringRef(attributes["reference"]),
+				ReferenceOrigin: stringRef(attributes["reference_origin"]),
+				Metadata:        metadataRef(attributes),
+			},
+		},
+	}
+
+	err := d.Set("type", subscriptionModelsType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	subscriptionModel, _, err := c.SubscriptionModelsApi.POSTSubscriptionModels(ctx).
+		SubscriptionModelCreate(subscriptionModelCreate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	d.SetId(subscriptionModel.Data.GetId().(string))
+
+	err = waitForCreated(ctx, c, d, subscriptionModelsType)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	return nil
+}
+
+func resourceSubscriptionModelDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+	_, err := c.SubscriptionModelsApi.DELETESubscriptionModelsSubscriptionModelId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, subscriptionModelsType)
+	return diag.FromErr(err)
+}
+
+func resourceSubscriptionModelUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
+	c := i.(*apiClient)
+
+	attributes := blockValues(d, "attributes", resourceSubscriptionModelAttributes())
+
+	var subscriptionModelUpdate = commercelayer.SubscriptionModelUpdate{
+		Data: commercelayer.SubscriptionModelUpdateData{
+			Type: subscriptionModelsType,
+			Id:   d.Id(),
+			Attributes: commercelayer.PATCHSubscriptionModelsSubscriptionModelId200ResponseDataAttributes{
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Strategy:        changedAttribute(d, "strategy", stringRef(attributes["strategy"])),
+				Frequencies:     changedAttribute(d, "frequencies", stringSliceValueRef(attributes["frequencies"])),
+				AutoActivate:    changedAttribute(d, "auto_activate", boolRef(attributes["auto_activate"])),
+				AutoCancel:      changedAttribute(d, "auto_cancel", boolRef(attributes["auto_cancel"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
+			},
+		},
+	}
+
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, subscriptionModelsType)
+		if err != nil {
+			return diagErr(err)
+		}
+		subscriptionModelUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	_, _, err := c.SubscriptionModelsApi.PATCHSubscriptionModelsSubscriptionModelId(ctx, d.Id()).
+		SubscriptionModelUpdate(subscriptionModelUpdate).Execute()
+
+	return diag.FromErr(err)
+}
@@ -0,0 +1,84 @@
+package commercelayer
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccCheckSubscriptionModelDestroy(s *terraform.State) error {
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "commercelayer_subscription_model" {
+			err := testAccWaitForRemoval(client, rs)
+			if err != nil {
+				return err
+			}
+		}
+
+	}
+	return nil
+}
+
+func (s *AcceptanceSuite) TestAccSubscriptionModel_basic() {
+	resourceName := "commercelayer_subscription_model.incentro_subscription_model"
+
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckSubscriptionModelDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSubscriptionModelCreate(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", subscriptionModelsType),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Subscription Model"),
+					resource.TestCheckResourceAttr(resourceName, "strategy", "by_frequency"),
+					resource.TestCheckResourceAttr(resourceName, "frequencies.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "auto_activate", "true"),
+					resource.TestCheckResourceAttr(resourceName, "auto_cancel", "false"),
+				),
+			},
+			{
+				Config: testAccSubscriptionModelUpdate(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Subscription Model Changed"),
+					resource.TestCheckResourceAttr(resourceName, "strategy", "by_line_items"),
+					resource.TestCheckResourceAttr(resourceName, "frequencies.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "frequencies.2", "0 8 * * 1"),
+					resource.TestCheckResourceAttr(resourceName, "auto_activate", "false"),
+					resource.TestCheckResourceAttr(resourceName, "auto_cancel", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSubscriptionModelCreate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_subscription_model" "incentro_subscription_model" {
+			name        = "Incentro Subscription Model"
+			frequencies = ["weekly", "monthly"]
+			metadata = {
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
+}
+
+func testAccSubscriptionModelUpdate(testName string) string {
+	return hclTemplate(`
+		resource "commercelayer_subscription_model" "incentro_subscription_model" {
+			name          = "Incentro Subscription Model Changed"
+			strategy      = "by_line_items"
+			frequencies   = ["weekly", "monthly", "0 8 * * 1"]
+			auto_activate = false
+			auto_cancel   = true
+			metadata = {
+			  testName: "{{.testName}}"
+			}
+		}
+	`, map[string]any{"testName": testName})
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func resourceTaxjarAccount() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "Configure your TaxJar account to automatically compute tax calculations " +
 			"for the orders of the associated market.",
 		ReadContext:   resourceTaxjarAccountReadFunc,
@@ -18,6 +18,7 @@ func resourceTaxjarAccount() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The taxjar account unique identifier",
@@ -29,46 +30,61 @@ func resourceTaxjarAccount() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "The tax calculator's internal name.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"api_key": {
-							Description: "The TaxJar account API key.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"attributes": resourceTaxjarAccountAttributes(),
+		},
+	})
+}
+
+func resourceTaxjarAccountAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "The tax calculator's internal name.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"api_key": {
+					Description: "The TaxJar account API key.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -76,9 +92,9 @@ func resourceTaxjarAccount() *schema.Resource {
 }
 
 func resourceTaxjarAccountReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.TaxjarAccountsApi.GETTaxjarAccountsTaxjarAccountId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.TaxjarAccountsApi.GETTaxjarAccountsTaxjarAccountId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -91,13 +107,23 @@ func resourceTaxjarAccountReadFunc(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(taxjarAccount.GetId().(string))
 
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceTaxjarAccountCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceTaxjarAccountAttributes())
 
 	taxjarAccountCreate := commercelayer.TaxjarAccountCreate{
 		Data: commercelayer.TaxjarAccountCreateData{
@@ -107,7 +133,7 @@ func resourceTaxjarAccountCreateFunc(ctx context.Context, d *schema.ResourceData
 				ApiKey:          attributes["api_key"].(string),
 				Reference:       stringRef(attributes["reference"]),
 				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Metadata:        metadataRef(attributes),
 			},
 		},
 	}
@@ -124,33 +150,50 @@ func resourceTaxjarAccountCreateFunc(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(taxjarAccount.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, taxjarAccountsType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceTaxjarAccountDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.TaxjarAccountsApi.DELETETaxjarAccountsTaxjarAccountId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, taxjarAccountsType)
 	return diag.FromErr(err)
 }
 
 func resourceTaxjarAccountUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceTaxjarAccountAttributes())
 
 	var taxjarAccountUpdate = commercelayer.TaxjarAccountUpdate{
 		Data: commercelayer.TaxjarAccountUpdateData{
 			Type: taxjarAccountsType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHTaxjarAccountsTaxjarAccountId200ResponseDataAttributes{
-				Name:            stringRef(attributes["name"]),
-				Reference:       stringRef(attributes["reference"]),
-				ReferenceOrigin: stringRef(attributes["reference_origin"]),
-				Metadata:        keyValueRef(attributes["metadata"]),
+				Name:            changedAttribute(d, "name", stringRef(attributes["name"])),
+				Reference:       changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin: changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, taxjarAccountsType)
+		if err != nil {
+			return diagErr(err)
+		}
+		taxjarAccountUpdate.Data.Attributes.Metadata = metadata
+	}
+
 	_, _, err := c.TaxjarAccountsApi.PATCHTaxjarAccountsTaxjarAccountId(ctx, d.Id()).
 		TaxjarAccountUpdate(taxjarAccountUpdate).Execute()
 
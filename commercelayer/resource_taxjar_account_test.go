@@ -5,11 +5,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
 func testAccCheckTaxjarAccountDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_taxjar_accounts" {
@@ -37,22 +36,22 @@ func (s *AcceptanceSuite) TestAccTaxjarAccount_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckTaxjarAccountDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckTaxjarAccountDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccTaxjarAccountCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", taxjarAccountsType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Taxjar Account"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Taxjar Account"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 				),
 			},
 			{
 				Config: testAccTaxjarAccountUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "Incentro Taxjar Account Changed"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "Incentro Taxjar Account Changed"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 				),
 			},
 		},
@@ -62,14 +61,12 @@ func (s *AcceptanceSuite) TestAccTaxjarAccount_basic() {
 func testAccTaxjarAccountCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_taxjar_accounts" "incentro_taxjar_account" {
-           attributes {
 			name = "Incentro Taxjar Account"
 			api_key = "TAXJAR_API_KEY"
 			metadata = {
 				foo: "bar"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
@@ -77,14 +74,12 @@ func testAccTaxjarAccountCreate(testName string) string {
 func testAccTaxjarAccountUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_taxjar_accounts" "incentro_taxjar_account" {
-           attributes {
 			name                   = "Incentro Taxjar Account Changed"
 			api_key = "TAXJAR_API_KEY"
 			metadata = {
 				bar: "foo"
 				testName: "{{.testName}}"
     		}
-  		}
 	}
 `, map[string]any{"testName": testName})
 }
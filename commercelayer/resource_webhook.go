@@ -2,13 +2,20 @@ package commercelayer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
+const (
+	webhookCircuitOpen   = "open"
+	webhookCircuitClosed = "closed"
+)
+
 func resourceWebhook() *schema.Resource {
-	return &schema.Resource{
+	return flattenedResource(&schema.Resource{
 		Description: "A webhook object is returned as part of the response body of each successful list, retrieve, " +
 			"create or update API call to the /api/webhooks endpoint.",
 		ReadContext:   resourceWebhookReadFunc,
@@ -18,6 +25,7 @@ func resourceWebhook() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The webhook unique identifier",
@@ -35,61 +43,100 @@ func resourceWebhook() *schema.Resource {
 				Computed:    true,
 				Sensitive:   true,
 			},
-			"attributes": {
-				Description: "Resource attributes",
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				MinItems:    1,
-				Required:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Description: "Unique name for the webhook.",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "webhook",
-						},
-						"topic": {
-							Description: "The identifier of the resource/event that will trigger the webhook.",
-							Type:        schema.TypeString,
-							Required:    true,
-						},
-						"callback_url": {
-							Description: "URI where the webhook subscription should send the POST request when the " +
-								"event occurs.",
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"include_resources": {
-							Description: "List of related commercelayer_inventory_stock_location that should be included in the webhook body.",
-							Type:        schema.TypeList,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
-						"reference": {
-							Description: "A string that you can use to add any external identifier to the resource. This " +
-								"can be useful for integrating the resource to an external system, like an ERP, a " +
-								"marketing tool, a CRM, or whatever.",
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"reference_origin": {
-							Description: "Any identifier of the third party system that defines the reference code",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"metadata": {
-							Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
-								"for storing additional information about the resource in a structured format",
-							Type: schema.TypeMap,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-							Optional: true,
-						},
+			"circuit_state": {
+				Description: "The circuit breaker state, by default it is 'closed'. It can become 'open' once the " +
+					"number of consecutive failures overlaps the specified threshold, in such case no further calls " +
+					"to the failing callback are made.",
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"circuit_failure_count": {
+				Description: "The number of consecutive failures recorded by the circuit breaker associated to " +
+					"this resource, will be reset on first successful call to callback.",
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"attributes": resourceWebhookAttributes(),
+		},
+		CustomizeDiff: resourceWebhookCustomizeDiff,
+	})
+}
+
+func resourceWebhookAttributes() *schema.Schema {
+	return &schema.Schema{
+		Description: "Resource attributes",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		MinItems:    1,
+		Required:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "Unique name for the webhook.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "webhook",
+				},
+				"topic": {
+					Description:      "The identifier of the resource/event that will trigger the webhook.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: webhookTopicValidation,
+				},
+				"callback_url": {
+					Description: "URI where the webhook subscription should send the POST request when the " +
+						"event occurs.",
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"include_resources": {
+					Description: "List of related resources that should be included in the webhook body. Must be " +
+						"relationships of the resource of the topic, for example customer or line_items.item for an " +
+						"orders topic.",
+					Type: schema.TypeList,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"reset_circuit": {
+					Description: "Send this attribute if you want to reset the circuit breaker associated to this " +
+						"resource to 'closed' state and zero failures count. The reset happens on apply while the " +
+						"circuit is 'open', so it can be left set to re-arm the webhook whenever it trips.",
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"reference": {
+					Description: "A string that you can use to add any external identifier to the resource. This " +
+						"can be useful for integrating the resource to an external system, like an ERP, a " +
+						"marketing tool, a CRM, or whatever.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reference_origin": {
+					Description: "Any identifier of the third party system that defines the reference code",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"metadata": {
+					Description: "Set of key-value pairs that you can attach to the resource. This can be useful " +
+						"for storing additional information about the resource in a structured format",
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
 					},
+					Optional: true,
+				},
+				"metadata_json": {
+					Description: "Set of key-value pairs that you can attach to the resource as a JSON object, for example " +
+						"the output of jsonencode. Unlike metadata, values can be numbers, lists or nested objects. " +
+						"Only the keys set here are managed, keys that other systems add to the metadata are kept and " +
+						"are not reported as changes.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: jsonObjectValidation,
+					StateFunc:        normalizeJson,
+					ConflictsWith:    []string{"attributes.0.metadata"},
 				},
 			},
 		},
@@ -97,9 +144,9 @@ func resourceWebhook() *schema.Resource {
 }
 
 func resourceWebhookReadFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	resp, _, err := c.WebhooksApi.GETWebhooksWebhookId(ctx, d.Id()).Execute()
+	resp, httpResp, err := c.WebhooksApi.GETWebhooksWebhookId(ctx, d.Id()).Execute()
 	if err != nil {
 		return diagErr(err)
 	}
@@ -112,13 +159,28 @@ func resourceWebhookReadFunc(ctx context.Context, d *schema.ResourceData, i inte
 
 	d.SetId(webhook.GetId().(string))
 
+	err = setWebhookCircuit(d, webhook.GetAttributes())
+	if err != nil {
+		return diagErr(err)
+	}
+
+	metadata, err := metadataFromResponse(httpResp)
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = setMetadataJson(d, metadata)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceWebhookCreateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceWebhookAttributes())
 
 	webhookCreate := commercelayer.WebhookCreate{
 		Data: commercelayer.WebhookCreateData{
@@ -130,7 +192,7 @@ func resourceWebhookCreateFunc(ctx context.Context, d *schema.ResourceData, i in
 				IncludeResources: stringSliceValueRef(attributes["include_resources"]),
 				Reference:        stringRef(attributes["reference"]),
 				ReferenceOrigin:  stringRef(attributes["reference_origin"]),
-				Metadata:         keyValueRef(attributes["metadata"]),
+				Metadata:         metadataRef(attributes),
 			},
 		},
 	}
@@ -147,6 +209,11 @@ func resourceWebhookCreateFunc(ctx context.Context, d *schema.ResourceData, i in
 
 	d.SetId(webhook.Data.GetId().(string))
 
+	err = waitForCreated(ctx, c, d, webhookType)
+	if err != nil {
+		return diagErr(err)
+	}
+
 	//Fetch the shared secret (this is a work-around because the create does not return it)
 	resp, _, err := c.WebhooksApi.GETWebhooksWebhookId(ctx, webhook.Data.GetId().(string)).Execute()
 	if err != nil {
@@ -160,38 +227,100 @@ func resourceWebhookCreateFunc(ctx context.Context, d *schema.ResourceData, i in
 		return diagErr(err)
 	}
 
+	err = setWebhookCircuit(d, getWebhook.GetAttributes())
+	if err != nil {
+		return diagErr(err)
+	}
+
 	return nil
 }
 
 func resourceWebhookDeleteFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 	_, err := c.WebhooksApi.DELETEWebhooksWebhookId(ctx, d.Id()).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	err = waitForDeleted(ctx, c, d, webhookType)
 	return diag.FromErr(err)
 }
 
 func resourceWebhookUpdateFunc(ctx context.Context, d *schema.ResourceData, i interface{}) diag.Diagnostics {
-	c := i.(*commercelayer.APIClient)
+	c := i.(*apiClient)
 
-	attributes := nestedMap(d.Get("attributes"))
+	attributes := blockValues(d, "attributes", resourceWebhookAttributes())
 
 	var webhookUpdate = commercelayer.WebhookUpdate{
 		Data: commercelayer.WebhookUpdateData{
 			Type: webhookType,
 			Id:   d.Id(),
 			Attributes: commercelayer.PATCHWebhooksWebhookId200ResponseDataAttributes{
-				Name:             stringRef(attributes["name"]),
-				Topic:            stringRef(attributes["topic"]),
-				CallbackUrl:      stringRef(attributes["callback_url"]),
-				IncludeResources: stringSliceValueRef(attributes["include_resources"]),
-				Reference:        stringRef(attributes["reference"]),
-				ReferenceOrigin:  stringRef(attributes["reference_origin"]),
-				Metadata:         keyValueRef(attributes["metadata"]),
+				Name:             changedAttribute(d, "name", stringRef(attributes["name"])),
+				Topic:            changedAttribute(d, "topic", stringRef(attributes["topic"])),
+				CallbackUrl:      changedAttribute(d, "callback_url", stringRef(attributes["callback_url"])),
+				IncludeResources: changedAttribute(d, "include_resources", stringSliceValueRef(attributes["include_resources"])),
+				Reference:        changedAttribute(d, "reference", stringRef(attributes["reference"])),
+				ReferenceOrigin:  changedAttribute(d, "reference_origin", stringRef(attributes["reference_origin"])),
 			},
 		},
 	}
 
+	if hasAttributeChange(d, "metadata") || hasAttributeChange(d, "metadata_json") {
+		metadata, err := mergeMetadata(ctx, c, d, webhookType)
+		if err != nil {
+			return diagErr(err)
+		}
+		webhookUpdate.Data.Attributes.Metadata = metadata
+	}
+
+	circuitState, _ := d.GetChange("circuit_state")
+	resetCircuit := attributes["reset_circuit"].(bool) && circuitState.(string) == webhookCircuitOpen
+	if resetCircuit {
+		webhookUpdate.Data.Attributes.ResetCircuit = true
+	}
+
 	_, _, err := c.WebhooksApi.PATCHWebhooksWebhookId(ctx, d.Id()).WebhookUpdate(webhookUpdate).Execute()
+	if err != nil {
+		return diagErr(err)
+	}
+
+	if resetCircuit {
+		err = errors.Join(d.Set("circuit_state", webhookCircuitClosed), d.Set("circuit_failure_count", 0))
+	}
 
 	return diag.FromErr(err)
+}
+
+// resourceWebhookCustomizeDiff checks the included resources against the relationships of the topic, and plans the
+// circuit breaker to change when reset_circuit re-arms an open circuit.
+func resourceWebhookCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	topicKey, includeResourcesKey := attributeKey(d, "topic"), attributeKey(d, "include_resources")
+	if d.NewValueKnown(topicKey) && d.NewValueKnown(includeResourcesKey) {
+		var includeResources []string
+		for _, includeResource := range d.Get(includeResourcesKey).([]interface{}) {
+			s, _ := includeResource.(string)
+			includeResources = append(includeResources, s)
+		}
+		err := validateIncludeResources(d.Get(topicKey).(string), includeResources)
+		if err != nil {
+			return fmt.Errorf("include_resources: %w", err)
+		}
+	}
+
+	resetCircuitKey := attributeKey(d, "reset_circuit")
+	if d.Id() != "" && d.Get(resetCircuitKey).(bool) && d.Get("circuit_state").(string) == webhookCircuitOpen {
+		return errors.Join(d.SetNewComputed("circuit_state"), d.SetNewComputed("circuit_failure_count"))
+	}
+
+	return nil
+}
+
+// setWebhookCircuit sets the state of the circuit breaker of a webhook. The generated SDK leaves these attributes
+// untyped, so the failure count arrives as a JSON number.
+func setWebhookCircuit(d *schema.ResourceData, attributes commercelayer.GETWebhooksWebhookId200ResponseDataAttributes) error {
+	circuitState, _ := attributes.GetCircuitState().(string)
+	circuitFailureCount, _ := attributes.GetCircuitFailureCount().(float64)
 
+	return errors.Join(d.Set("circuit_state", circuitState), d.Set("circuit_failure_count", int(circuitFailureCount)))
 }
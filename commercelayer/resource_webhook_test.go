@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+	"regexp"
 )
 
 func testAccCheckWebhookDestroy(s *terraform.State) error {
-	client := testAccProviderCommercelayer.Meta().(*commercelayer.APIClient)
+	client := testAccProviderCommercelayer.Meta().(*apiClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "commercelayer_webhook" {
@@ -36,29 +36,31 @@ func (s *AcceptanceSuite) TestAccWebhook_basic() {
 		PreCheck: func() {
 			testAccPreCheck(s)
 		},
-		ProviderFactories: testAccProviderFactories,
-		CheckDestroy:      testAccCheckWebhookDestroy,
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		CheckDestroy:             testAccCheckWebhookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccWebhookCreate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "type", webhookType),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro webhook"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.topic", "orders.create"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.callback_url", "http://example.url"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.include_resources.0", "customer"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro webhook"),
+					resource.TestCheckResourceAttr(resourceName, "topic", "orders.create"),
+					resource.TestCheckResourceAttr(resourceName, "callback_url", "http://example.url"),
+					resource.TestCheckResourceAttr(resourceName, "include_resources.0", "customer"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.foo", "bar"),
 					resource.TestCheckResourceAttrSet(resourceName, "shared_secret"),
+					resource.TestCheckResourceAttr(resourceName, "circuit_state", "closed"),
+					resource.TestCheckResourceAttr(resourceName, "circuit_failure_count", "0"),
 				),
 			},
 			{
 				Config: testAccWebhookUpdate(resourceName),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.name", "incentro updated webhook"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.topic", "orders.place"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.callback_url", "http://other-example.url"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.include_resources.0", "line_items"),
-					resource.TestCheckResourceAttr(resourceName, "attributes.0.metadata.bar", "foo"),
+					resource.TestCheckResourceAttr(resourceName, "name", "incentro updated webhook"),
+					resource.TestCheckResourceAttr(resourceName, "topic", "orders.place"),
+					resource.TestCheckResourceAttr(resourceName, "callback_url", "http://other-example.url"),
+					resource.TestCheckResourceAttr(resourceName, "include_resources.0", "line_items"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.bar", "foo"),
 					resource.TestCheckResourceAttrSet(resourceName, "shared_secret"),
 				),
 			},
@@ -66,10 +68,35 @@ func (s *AcceptanceSuite) TestAccWebhook_basic() {
 	})
 }
 
+func (s *AcceptanceSuite) TestAccWebhook_validation() {
+	resource.Test(s.T(), resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(s)
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWebhookValidation("order.place", "customer"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`did you mean orders\.place\?`),
+			},
+			{
+				Config:      testAccWebhookValidation("orders", "customer"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Must be a resource/event pair`),
+			},
+			{
+				Config:      testAccWebhookValidation("orders.place", "custmer"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`custmer is not a relationship of the orders\.place topic`),
+			},
+		},
+	})
+}
+
 func testAccWebhookCreate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_webhook" "incentro_webhook" {
-		  attributes {
 			name         = "incentro webhook"
 			topic        = "orders.create"
 			callback_url = "http://example.url"
@@ -80,7 +107,6 @@ func testAccWebhookCreate(testName string) string {
 			  foo : "bar"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
@@ -88,7 +114,6 @@ func testAccWebhookCreate(testName string) string {
 func testAccWebhookUpdate(testName string) string {
 	return hclTemplate(`
 		resource "commercelayer_webhook" "incentro_webhook" {
-		  attributes {
 			name         = "incentro updated webhook"
 			topic        = "orders.place"
 			callback_url = "http://other-example.url"
@@ -99,7 +124,18 @@ func testAccWebhookUpdate(testName string) string {
 			  bar : "foo"
 		 	  testName: "{{.testName}}"
 			}
-		  }
 		}
 	`, map[string]any{"testName": testName})
 }
+
+func testAccWebhookValidation(topic string, includeResource string) string {
+	return hclTemplate(`
+		resource "commercelayer_webhook" "incentro_webhook" {
+			topic        = "{{.topic}}"
+			callback_url = "http://example.url"
+			include_resources = [
+			  "{{.includeResource}}"
+			]
+		}
+	`, map[string]any{"topic": topic, "includeResource": includeResource})
+}
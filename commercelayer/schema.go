@@ -0,0 +1,342 @@
+package commercelayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// blocks are the single item blocks that schema version 0 wrapped the fields of every resource in.
+var blocks = []string{"attributes", "relationships"}
+
+// fieldGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type fieldGetter interface {
+	Get(key string) interface{}
+}
+
+// changeGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type changeGetter interface {
+	fieldGetter
+	GetChange(key string) (interface{}, interface{})
+	GetRawConfig() cty.Value
+}
+
+// flattenedResource turns a resource defined with attributes and relationships blocks into schema version 1, which
+// has the fields of these blocks at the top level. The blocks are still accepted during a deprecation window. Moving
+// fields between a block and the top level, in the configuration or by the state upgrade, plans no changes as long as
+// their values stay the same.
+func flattenedResource(r *schema.Resource) *schema.Resource {
+	v0 := &schema.Resource{Schema: r.Schema, Timeouts: r.Timeouts}
+
+	fields := make(map[string]*schema.Schema, len(r.Schema))
+	required := map[string][]string{}
+	forceNew := map[string][]string{}
+	for key, field := range r.Schema {
+		fields[key] = field
+	}
+
+	for _, block := range blocks {
+		field, ok := r.Schema[block]
+		if !ok {
+			continue
+		}
+
+		nestedFields := make(map[string]*schema.Schema, len(field.Elem.(*schema.Resource).Schema))
+		suppressMoved := movedBlockDiffSuppressFunc(block, nestedFields)
+		for key, nestedField := range field.Elem.(*schema.Resource).Schema {
+			if _, ok := fields[key]; ok {
+				panic(fmt.Sprintf("field %s of the %s block is already defined at the top level", key, block))
+			}
+
+			// Moving a value between the block and the top level doesn't change it, so replacing the resource
+			// is left to forceNewFlattenedFields, which compares the values wherever they are set.
+			nested := *nestedField
+			if nested.ForceNew {
+				nested.ForceNew = false
+				forceNew[block] = append(forceNew[block], key)
+			}
+			nestedFields[key] = &nested
+
+			fields[key] = topLevelField(block, &nested, field.Required)
+			fields[key].DiffSuppressFunc = orDiffSuppressFunc(nested.DiffSuppressFunc, suppressMoved)
+			if field.Required && nestedField.Required {
+				required[block] = append(required[block], key)
+			}
+		}
+		sort.Strings(required[block])
+		sort.Strings(forceNew[block])
+
+		deprecated := *field
+		deprecated.Required = false
+		deprecated.Optional = true
+		deprecated.Deprecated = fmt.Sprintf("The %s block is deprecated, set its fields at the top level of "+
+			"the resource instead.", block)
+		deprecated.Elem = &schema.Resource{Schema: nestedFields}
+		deprecated.DiffSuppressFunc = suppressMoved
+		fields[block] = &deprecated
+	}
+
+	r.Schema = fields
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    v0.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeFlattenedState,
+		},
+	}
+
+	customizeDiff := []schema.CustomizeDiffFunc{requireFlattenedFields(required)}
+	if len(forceNew) > 0 {
+		customizeDiff = append(customizeDiff, forceNewFlattenedFields(fields, forceNew))
+	}
+	if r.CustomizeDiff != nil {
+		customizeDiff = append(customizeDiff, r.CustomizeDiff)
+	}
+	r.CustomizeDiff = customdiff.All(customizeDiff...)
+
+	return r
+}
+
+// topLevelField copies a field of a block to the top level. Fields that are required in the block are optional at the
+// top level, as they are not needed when the deprecated block is used; requireFlattenedFields checks them instead.
+func topLevelField(block string, field *schema.Schema, blockRequired bool) *schema.Schema {
+	topLevel := *field
+	topLevel.Required = false
+	topLevel.Optional = field.Optional || field.Required
+	if field.Required && blockRequired {
+		topLevel.Description = fmt.Sprintf("%s. Required, unless the deprecated %s block is used.",
+			strings.TrimSuffix(field.Description, "."), block)
+	}
+
+	topLevel.ConflictsWith = []string{block}
+	for _, key := range field.ConflictsWith {
+		topLevel.ConflictsWith = append(topLevel.ConflictsWith, strings.TrimPrefix(key, block+".0."))
+	}
+
+	return &topLevel
+}
+
+// requireFlattenedFields returns a CustomizeDiffFunc that checks the fields of required blocks are set at the top
+// level when the deprecated block is not used.
+func requireFlattenedFields(required map[string][]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		var errs []error
+		for _, block := range blocks {
+			if configHasBlock(config, block) {
+				continue
+			}
+			for _, key := range required[block] {
+				if config.GetAttr(key).IsNull() {
+					errs = append(errs, fmt.Errorf("the argument %q is required", key))
+				}
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// forceNewFlattenedFields returns a CustomizeDiffFunc that replaces the resource when a field of a block that forces a
+// new resource changes. The value in the state and the planned value are taken from the deprecated block when it is
+// used and from the top level otherwise, so that moving an unchanged value between them doesn't replace the resource.
+func forceNewFlattenedFields(fields map[string]*schema.Schema, forceNew map[string][]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		for _, block := range blocks {
+			for _, key := range forceNew[block] {
+				newKey, newValue := configuredBlockField(d, block, key, fields[key])
+				if d.NewValueKnown(newKey) && reflect.DeepEqual(stateBlockField(d, block, key), newValue) {
+					continue
+				}
+
+				otherKey := block + ".0." + key
+				if newKey == otherKey {
+					otherKey = key
+				}
+				for _, k := range []string{newKey, otherKey} {
+					if d.HasChange(k) {
+						if err := d.ForceNew(k); err != nil {
+							return err
+						}
+						break
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// movedBlockDiffSuppressFunc returns a SchemaDiffSuppressFunc for a block and the top-level copies of its fields, which
+// suppresses their changes when the values of the fields stay the same and only move between the deprecated block
+// and the top level. State that uses the block also holds the top-level copies, so this keeps the plan empty both for
+// a configuration that still uses the block and for one that moved its fields out of it.
+func movedBlockDiffSuppressFunc(block string, fields map[string]*schema.Schema) schema.SchemaDiffSuppressFunc {
+	return func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+		if d.Id() == "" {
+			return false
+		}
+
+		for key, field := range fields {
+			_, value := configuredBlockField(d, block, key, field)
+			if !reflect.DeepEqual(stateBlockField(d, block, key), value) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func orDiffSuppressFunc(funcs ...schema.SchemaDiffSuppressFunc) schema.SchemaDiffSuppressFunc {
+	return func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+		for _, f := range funcs {
+			if f != nil && f(k, oldValue, newValue, d) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// stateBlockField returns the value of a field of an attributes or relationships block in the state, taken from the
+// deprecated block when the state uses it and from the top level otherwise.
+func stateBlockField(d changeGetter, block string, key string) interface{} {
+	oldBlock, _ := d.GetChange(block)
+	if items, _ := oldBlock.([]interface{}); len(items) > 0 {
+		return nestedMap(oldBlock)[key]
+	}
+
+	oldValue, _ := d.GetChange(key)
+	return oldValue
+}
+
+// configuredBlockField returns the key and the planned value of a field of an attributes or relationships block,
+// taken from the deprecated block when the configuration uses it and from the top level otherwise. The value is
+// normalized like it is in the state.
+func configuredBlockField(d changeGetter, block string, key string, field *schema.Schema) (string, interface{}) {
+	newKey := key
+	if configHasBlock(d.GetRawConfig(), block) {
+		newKey = block + ".0." + key
+	}
+
+	value := d.Get(newKey)
+	if field.StateFunc != nil {
+		value = field.StateFunc(value)
+	}
+	return newKey, value
+}
+
+// upgradeFlattenedState upgrades state of schema version 0 by copying the fields of the attributes and relationships
+// blocks to the top level. The blocks are kept, so that configuration that still uses them and references into them
+// keeps working; they are dropped from the state once the configuration moves the fields out of them and a change is
+// applied.
+func upgradeFlattenedState(ctx context.Context, rawState map[string]interface{}, i interface{}) (map[string]interface{}, error) {
+	for _, block := range blocks {
+		items, _ := rawState[block].([]interface{})
+		if len(items) == 0 {
+			continue
+		}
+
+		values, _ := items[0].(map[string]interface{})
+		for key, value := range values {
+			rawState[key] = value
+		}
+	}
+
+	return rawState, nil
+}
+
+// blockInUse reports whether the deprecated attributes or relationships block is used.
+func blockInUse(d fieldGetter, block string) bool {
+	items, _ := d.Get(block).([]interface{})
+	return len(items) > 0
+}
+
+// blockValues returns the values of the fields of an attributes or relationships block, taken from the deprecated
+// block when it is used and from the top level otherwise.
+func blockValues(d fieldGetter, block string, field *schema.Schema) map[string]any {
+	if blockInUse(d, block) {
+		return nestedMap(d.Get(block))
+	}
+
+	values := map[string]any{}
+	for key := range field.Elem.(*schema.Resource).Schema {
+		values[key] = d.Get(key)
+	}
+	return values
+}
+
+// blockKey returns the key of a field of an attributes or relationships block, which points into the deprecated
+// block when it is used.
+func blockKey(d fieldGetter, block string, key string) string {
+	if blockInUse(d, block) {
+		return block + ".0." + key
+	}
+	return key
+}
+
+// attributeKey returns the key of a field of the attributes block.
+func attributeKey(d fieldGetter, key string) string {
+	return blockKey(d, "attributes", key)
+}
+
+// relationshipKey returns the key of a field of the relationships block.
+func relationshipKey(d fieldGetter, key string) string {
+	return blockKey(d, "relationships", key)
+}
+
+// hasBlockFieldChange reports whether a field of an attributes or relationships block changed. The value in the state
+// and the planned value are each taken from where they are set, so that moving a field between the deprecated block
+// and the top level is not reported as a change.
+func hasBlockFieldChange(d *schema.ResourceData, block string, key string) bool {
+	return !reflect.DeepEqual(stateBlockField(d, block, key), d.Get(blockKey(d, block, key)))
+}
+
+// hasAttributeChange reports whether a field of the attributes block changed.
+func hasAttributeChange(d *schema.ResourceData, key string) bool {
+	return hasBlockFieldChange(d, "attributes", key)
+}
+
+// hasRelationshipChange reports whether a field of the relationships block changed.
+func hasRelationshipChange(d *schema.ResourceData, key string) bool {
+	return hasBlockFieldChange(d, "relationships", key)
+}
+
+// setBlockField sets a field of an attributes or relationships block at the top level, and in the deprecated block as
+// well when it is used, so that the top-level copies stay current while a configuration moves off the block.
+func setBlockField(d *schema.ResourceData, block string, key string, value interface{}) error {
+	err := d.Set(key, value)
+	if err != nil || !blockInUse(d, block) {
+		return err
+	}
+
+	values := nestedMap(d.Get(block))
+	values[key] = value
+	return d.Set(block, []any{values})
+}
+
+func configHasBlock(config cty.Value, block string) bool {
+	if !config.Type().IsObjectType() || !config.Type().HasAttribute(block) {
+		return false
+	}
+
+	blockValue := config.GetAttr(block)
+	return !blockValue.IsNull() && blockValue.IsKnown() && blockValue.CanIterateElements() && blockValue.LengthInt() > 0
+}
@@ -0,0 +1,259 @@
+package commercelayer
+
+import (
+	"context"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFlattenedResource(t *testing.T) {
+	r := resourceMarket()
+
+	assert.Equal(t, 1, r.SchemaVersion)
+	assert.Len(t, r.StateUpgraders, 1)
+	assert.NotEmpty(t, r.Schema["attributes"].Deprecated)
+	assert.False(t, r.Schema["attributes"].Required)
+	assert.NotEmpty(t, r.Schema["relationships"].Deprecated)
+
+	assert.True(t, r.Schema["name"].Optional)
+	assert.False(t, r.Schema["name"].Required)
+	assert.Equal(t, []string{"attributes"}, r.Schema["name"].ConflictsWith)
+	assert.Equal(t, []string{"attributes", "metadata"}, r.Schema["metadata_json"].ConflictsWith)
+	assert.Equal(t, []string{"relationships"}, r.Schema["merchant_id"].ConflictsWith)
+}
+
+func TestUpgradeFlattenedState(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":   "xYZkjABcde",
+		"type": "markets",
+		"attributes": []interface{}{
+			map[string]interface{}{"name": "Incentro Market", "enabled": true, "metadata": map[string]interface{}{"foo": "bar"}},
+		},
+		"relationships": []interface{}{
+			map[string]interface{}{"merchant_id": "vZbKkyAbcd"},
+		},
+	}
+
+	upgraded, err := upgradeFlattenedState(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":   "xYZkjABcde",
+		"type": "markets",
+		"attributes": []interface{}{
+			map[string]interface{}{"name": "Incentro Market", "enabled": true, "metadata": map[string]interface{}{"foo": "bar"}},
+		},
+		"relationships": []interface{}{
+			map[string]interface{}{"merchant_id": "vZbKkyAbcd"},
+		},
+		"name":        "Incentro Market",
+		"enabled":     true,
+		"metadata":    map[string]interface{}{"foo": "bar"},
+		"merchant_id": "vZbKkyAbcd",
+	}, upgraded)
+}
+
+func TestUpgradeFlattenedStateEmptyBlock(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":            "xYZkjABcde",
+		"type":          "customer_groups",
+		"attributes":    []interface{}{map[string]interface{}{"name": "B2B"}},
+		"relationships": []interface{}{},
+	}
+
+	upgraded, err := upgradeFlattenedState(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "B2B", upgraded["name"])
+	assert.Equal(t, []interface{}{}, upgraded["relationships"])
+}
+
+// testCtyValue converts a value of a raw state or configuration to the type of a schema, with the fields it doesn't
+// set null. Values that already are cty values, like unknown ones, are used as they are.
+func testCtyValue(ty cty.Type, value any) cty.Value {
+	if value == nil {
+		return cty.NullVal(ty)
+	}
+	if v, ok := value.(cty.Value); ok {
+		return v
+	}
+
+	switch {
+	case ty.IsObjectType():
+		values := value.(map[string]any)
+		attributes := map[string]cty.Value{}
+		for key, attributeType := range ty.AttributeTypes() {
+			attributes[key] = testCtyValue(attributeType, values[key])
+		}
+		return cty.ObjectVal(attributes)
+	case ty.IsListType():
+		var elements []cty.Value
+		for _, element := range value.([]any) {
+			elements = append(elements, testCtyValue(ty.ElementType(), element))
+		}
+		if len(elements) == 0 {
+			return cty.ListValEmpty(ty.ElementType())
+		}
+		return cty.ListVal(elements)
+	case ty.IsMapType():
+		elements := map[string]cty.Value{}
+		for key, element := range value.(map[string]any) {
+			elements[key] = testCtyValue(ty.ElementType(), element)
+		}
+		return cty.MapVal(elements)
+	case ty == cty.Bool:
+		return cty.BoolVal(value.(bool))
+	default:
+		return cty.StringVal(value.(string))
+	}
+}
+
+// testPlan plans a configuration of a resource against a state of schema version 0, after it is upgraded.
+func testPlan(t *testing.T, r *schema.Resource, rawState map[string]any, rawConfig map[string]any) *terraform.InstanceDiff {
+	upgraded, err := upgradeFlattenedState(context.Background(), rawState, nil)
+	require.NoError(t, err)
+
+	_, diff := testPlanState(t, r, upgraded, rawConfig)
+	return diff
+}
+
+// testPlanState plans a configuration of a resource against a state of schema version 1.
+func testPlanState(t *testing.T, r *schema.Resource, rawState map[string]any,
+	rawConfig map[string]any) (*terraform.InstanceState, *terraform.InstanceDiff) {
+	coreSchema := r.CoreConfigSchema()
+	state := terraform.NewInstanceStateShimmedFromValue(testCtyValue(coreSchema.ImpliedType(), rawState), 1)
+	config := testCtyValue(coreSchema.ImpliedType(), rawConfig)
+	state.RawConfig = config
+
+	diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigShimmed(config, coreSchema), nil)
+	require.NoError(t, err)
+	return state, diff
+}
+
+func testCustomerAddressConfig(email string, metadata map[string]any) map[string]any {
+	config := map[string]any{
+		"customer_email": email,
+		"customer_id":    "vZbKkyAbcd",
+		"address_id":     "wAbKkyAbcd",
+	}
+	if metadata != nil {
+		config["metadata"] = metadata
+	}
+	return config
+}
+
+func testCustomerAddressState() map[string]any {
+	return map[string]any{
+		"id":   "xYZkjABcde",
+		"type": customerAddressesType,
+		"attributes": []any{
+			map[string]any{"customer_email": "b2b-buyer@incentro.com", "metadata": map[string]any{"foo": "bar"}},
+		},
+		"relationships": []any{
+			map[string]any{"customer_id": "vZbKkyAbcd", "address_id": "wAbKkyAbcd"},
+		},
+	}
+}
+
+func TestFlattenedResourcePlanUnchangedBlocks(t *testing.T) {
+	diff := testPlan(t, resourceCustomerAddress(), testCustomerAddressState(), map[string]any{
+		"attributes": []any{
+			map[string]any{"customer_email": "B2B-Buyer@Incentro.com", "metadata": map[string]any{"foo": "bar"}},
+		},
+		"relationships": []any{
+			map[string]any{"customer_id": "vZbKkyAbcd", "address_id": "wAbKkyAbcd"},
+		},
+	})
+
+	assert.True(t, diff == nil || diff.Empty(), "expected an empty plan, got %v", diff)
+}
+
+func TestFlattenedResourcePlanMovedToTopLevel(t *testing.T) {
+	diff := testPlan(t, resourceCustomerAddress(), testCustomerAddressState(),
+		testCustomerAddressConfig("B2B-Buyer@Incentro.com", map[string]any{"foo": "bar"}))
+
+	assert.True(t, diff == nil || diff.Empty(), "expected an empty plan, got %v", diff)
+}
+
+func TestFlattenedResourcePlanBlockStateMovedToTopLevel(t *testing.T) {
+	// State of schema version 1 keeps the blocks of resources that were created with them.
+	_, diff := testPlanState(t, resourceCustomerAddress(), testCustomerAddressState(),
+		testCustomerAddressConfig("B2B-Buyer@Incentro.com", map[string]any{"foo": "bar"}))
+
+	assert.True(t, diff == nil || diff.Empty(), "expected an empty plan, got %v", diff)
+}
+
+func TestFlattenedResourcePlanMovedAndChanged(t *testing.T) {
+	r := resourceCustomerAddress()
+	state, diff := testPlanState(t, r, testCustomerAddressState(),
+		testCustomerAddressConfig("b2b-buyer@incentro.com", map[string]any{"foo": "baz"}))
+
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, "0", diff.Attributes["attributes.#"].New)
+	assert.Equal(t, "baz", diff.Attributes["metadata.foo"].New)
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+	assert.True(t, hasAttributeChange(d, "metadata"))
+	assert.False(t, hasAttributeChange(d, "customer_email"))
+	assert.False(t, hasRelationshipChange(d, "customer_id"))
+}
+
+func TestFlattenedResourcePlanForceNew(t *testing.T) {
+	r := resourceCustomerAddress()
+	assert.False(t, r.Schema["customer_email"].ForceNew)
+	assert.False(t, r.Schema["attributes"].Elem.(*schema.Resource).Schema["customer_email"].ForceNew)
+
+	diff := testPlan(t, r, testCustomerAddressState(), testCustomerAddressConfig("b2c-buyer@incentro.com", nil))
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+
+	diff = testPlan(t, r, testCustomerAddressState(), map[string]any{
+		"attributes":    []any{map[string]any{"customer_email": "b2c-buyer@incentro.com"}},
+		"relationships": []any{map[string]any{"customer_id": "vZbKkyAbcd", "address_id": "wAbKkyAbcd"}},
+	})
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestBlockValuesTopLevel(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"name": "manual",
+	})
+
+	assert.Equal(t, "manual", blockValues(d, "attributes", resourceManualGatewayAttributes())["name"])
+	assert.Equal(t, "name", attributeKey(d, "name"))
+}
+
+func TestBlockValuesDeprecatedBlock(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{"name": "manual"}},
+	})
+
+	assert.Equal(t, "manual", blockValues(d, "attributes", resourceManualGatewayAttributes())["name"])
+	assert.Equal(t, "attributes.0.name", attributeKey(d, "name"))
+}
+
+func TestSetBlockField(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"name": "manual",
+	})
+
+	assert.NoError(t, setBlockField(d, "attributes", "enabled", false))
+	assert.False(t, d.Get("enabled").(bool))
+	assert.Empty(t, d.Get("attributes"))
+}
+
+func TestSetBlockFieldDeprecatedBlock(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{"name": "manual"}},
+	})
+
+	assert.NoError(t, setBlockField(d, "attributes", "enabled", false))
+	assert.False(t, d.Get("attributes.0.enabled").(bool))
+	assert.False(t, d.Get("enabled").(bool))
+	assert.Equal(t, "manual", d.Get("attributes.0.name"))
+}
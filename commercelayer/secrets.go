@@ -0,0 +1,38 @@
+package commercelayer
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// credentialFields are the fields that hold credentials without being marked sensitive, next to the password and token
+// fields whose values are never returned either.
+var credentialFields = []string{
+	"api_key", "api_secret", "client_secret", "key", "login", "password", "private_key", "secret_key", "token",
+}
+
+// secretFields returns the fields whose values are secrets: the credential fields and every sensitive field of the
+// resources, including the fields of their blocks. The export writes them as variables and the recorder scrubs them
+// from cassettes, so a field that is marked sensitive is kept out of both.
+var secretFields = sync.OnceValue(func() map[string]bool {
+	fields := map[string]bool{}
+	for _, key := range credentialFields {
+		fields[key] = true
+	}
+	for _, r := range baseResourceMap {
+		addSensitiveFields(fields, r.Schema)
+	}
+	return fields
+})
+
+func addSensitiveFields(fields map[string]bool, s map[string]*schema.Schema) {
+	for key, field := range s {
+		if field.Sensitive {
+			fields[key] = true
+		}
+		if nested, ok := field.Elem.(*schema.Resource); ok {
+			addSensitiveFields(fields, nested.Schema)
+		}
+	}
+}
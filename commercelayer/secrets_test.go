@@ -0,0 +1,23 @@
+package commercelayer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSecretFields(t *testing.T) {
+	fields := secretFields()
+	assert.True(t, fields["password"])
+	assert.True(t, fields["token"])
+	assert.True(t, fields["client_secret"])
+	assert.True(t, fields["webhook_endpoint_secret"])
+	assert.True(t, fields["shared_secret"])
+	assert.False(t, fields["name"])
+	assert.False(t, fields["public_key"])
+}
+
+func TestSecretFieldsNestedSensitive(t *testing.T) {
+	fields := map[string]bool{}
+	addSensitiveFields(fields, resourceSatispayGateway().Schema)
+	assert.Equal(t, map[string]bool{"token": true}, fields)
+}
@@ -0,0 +1,38 @@
+package commercelayer
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns a protocol version 6 server that serves the SDKv2 provider and the framework provider side
+// by side. A resource is served by the half that defines it, so resources can be moved to the framework one by one.
+func ProviderServer(ctx context.Context, opts ...ProviderOption) (func() tfprotov6.ProviderServer, error) {
+	return newProviderServer(ctx, Provider(opts...)(), FrameworkProvider(opts...)())
+}
+
+func newProviderServer(ctx context.Context, sdkProvider *schema.Provider,
+	frameworkProvider provider.Provider) (func() tfprotov6.ProviderServer, error) {
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		func() tfprotov6.ProviderServer {
+			return upgradedSdkServer
+		},
+		providerserver.NewProtocol6(frameworkProvider),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
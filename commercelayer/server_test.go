@@ -0,0 +1,87 @@
+package commercelayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProviderSchema(t *testing.T) *tfprotov6.GetProviderSchemaResponse {
+	providerServer, err := ProviderServer(context.Background())
+	require.NoError(t, err)
+
+	resp, err := providerServer().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, diagnostic := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov6.DiagnosticSeverityError, diagnostic.Severity, diagnostic.Detail)
+	}
+
+	return resp
+}
+
+func TestProviderServerSchema(t *testing.T) {
+	resp := testProviderSchema(t)
+
+	assert.Len(t, resp.Provider.Block.Attributes, len(baseSchema))
+	assert.Len(t, resp.ResourceSchemas, len(baseResourceMap))
+}
+
+func TestProviderServerSchemaEnvironment(t *testing.T) {
+	t.Setenv("COMMERCELAYER_CLIENT_ID", "client-id")
+	t.Setenv("COMMERCELAYER_API_ENDPOINT", "https://example.commercelayer.io/api")
+
+	resp := testProviderSchema(t)
+
+	for _, attribute := range resp.Provider.Block.Attributes {
+		switch attribute.Name {
+		case "client_id", "api_endpoint":
+			assert.True(t, attribute.Optional, attribute.Name)
+		case "client_secret", "auth_endpoint":
+			assert.True(t, attribute.Required, attribute.Name)
+		}
+	}
+}
+
+func TestStringSetting(t *testing.T) {
+	t.Setenv("COMMERCELAYER_POLL_INTERVAL", "2s")
+
+	var diags diag.Diagnostics
+	assert.Equal(t, "5s", stringSetting(types.StringValue("5s"), "poll_interval", &diags))
+	assert.Equal(t, "2s", stringSetting(types.StringNull(), "poll_interval", &diags))
+	assert.Equal(t, "10s", stringSetting(types.StringNull(), "max_poll_interval", &diags))
+	assert.Equal(t, "", stringSetting(types.StringNull(), "client_secret", &diags))
+	assert.False(t, diags.HasError())
+}
+
+func TestBoolSetting(t *testing.T) {
+	var diags diag.Diagnostics
+	assert.False(t, boolSetting(types.BoolValue(false), "rate_limiter", &diags))
+	assert.True(t, boolSetting(types.BoolNull(), "rate_limiter", &diags))
+	assert.False(t, diags.HasError())
+}
+
+func TestProviderServerEphemeralResources(t *testing.T) {
+	resp := testProviderSchema(t)
+
+	assert.Contains(t, resp.EphemeralResourceSchemas, "commercelayer_access_token")
+}
+
+func TestProviderServerFunctions(t *testing.T) {
+	resp := testProviderSchema(t)
+
+	for _, name := range []string{"cents", "amount", "zone_matches", "webhook_signature"} {
+		assert.Contains(t, resp.Functions, name)
+	}
+}
+
+func TestProviderServerDataSources(t *testing.T) {
+	resp := testProviderSchema(t)
+
+	assert.Contains(t, resp.DataSourceSchemas, "commercelayer_market_readiness")
+	assert.Contains(t, resp.DataSourceSchemas, "commercelayer_webhook_event_callbacks")
+}
@@ -30,6 +30,14 @@ const (
 	braintreeGatewaysType        = "braintree_gateways"
 	checkoutComGatewaysType      = "checkout_com_gateways"
 	stripeGatewaysType           = "stripe_gateways"
+	satispayGatewaysType         = "satispay_gateways"
+	axerveGatewaysType           = "axerve_gateways"
 	manualTaxCalculatorsType     = "manual_tax_calculators"
 	taxjarAccountsType           = "taxjar_accounts"
+	subscriptionModelsType       = "subscription_models"
+	customersType                = "customers"
+	customerAddressesType        = "customer_addresses"
+	tagsType                     = "tags"
+	priceListSchedulersType      = "price_list_schedulers"
+	pricesType                   = "prices"
 )
@@ -1,7 +1,10 @@
 package commercelayer
 
 import (
+	"encoding/json"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
 )
 
@@ -91,3 +94,118 @@ func nestedMap(val interface{}) map[string]any {
 
 	return valMap[0].(map[string]any)
 }
+
+// metadataRef returns the metadata of the attributes block, taken from metadata_json when it is set and from the
+// string-only metadata map otherwise.
+func metadataRef(attributes map[string]any) map[string]interface{} {
+	metadataJson, _ := attributes["metadata_json"].(string)
+	if metadataJson == "" {
+		return keyValueRef(attributes["metadata"])
+	}
+
+	metadata := map[string]interface{}{}
+	err := decodeJson([]byte(metadataJson), &metadata)
+	if err != nil {
+		return keyValueRef(attributes["metadata"])
+	}
+	return metadata
+}
+
+// setMetadataJson refreshes the metadata_json attribute with the remote values of the configured keys, so that changes
+// to them are reported. Keys that other systems added are left out on purpose: mergeMetadata keeps them in place on
+// updates, so reporting them would show changes that no apply removes. This also applies after an import, where
+// metadata_json stays empty until it is configured.
+func setMetadataJson(d *schema.ResourceData, remote map[string]any) error {
+	metadataJson, _ := d.Get(attributeKey(d, "metadata_json")).(string)
+	if metadataJson == "" {
+		return nil
+	}
+
+	configured := map[string]any{}
+	err := decodeJson([]byte(metadataJson), &configured)
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]any{}
+	for key := range configured {
+		if value, ok := remote[key]; ok {
+			metadata[key] = value
+		}
+	}
+
+	refreshed, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	return setBlockField(d, "attributes", "metadata_json", string(refreshed))
+}
+
+// setEnabled refreshes the enabled attribute, a resource is enabled as long as it has no disabled_at timestamp.
+func setEnabled(d *schema.ResourceData, disabledAt interface{}) error {
+	return setBlockField(d, "attributes", "enabled", disabledAt == nil)
+}
+
+// changedAttribute returns the value of an attribute for update requests when it changed.
+// Unchanged attributes are nil, which leaves them out of the PATCH payload built by the generated SDK models.
+// All attributes are sent for new resources, which are only updated when an existing resource is adopted.
+func changedAttribute(d *schema.ResourceData, key string, value interface{}) interface{} {
+	if !d.IsNewResource() && !hasAttributeChange(d, key) {
+		return nil
+	}
+	return value
+}
+
+// configuredInt32Ref returns a reference to an integer attribute for update requests. Unlike
+// intToInt32Ref a 0 that is set in the configuration is kept, so it is only nil when the attribute was removed,
+// which clears it.
+func configuredInt32Ref(d *schema.ResourceData, key string) *int32 {
+	value := d.Get(attributeKey(d, key)).(int)
+	if value == 0 && !isConfigured(d, "attributes", key) {
+		return nil
+	}
+
+	ref := int32(value)
+	return &ref
+}
+
+// configuredFloat32Ref is the float variant of configuredInt32Ref.
+func configuredFloat32Ref(d *schema.ResourceData, key string) *float32 {
+	value := d.Get(attributeKey(d, key)).(float64)
+	if value == 0 && !isConfigured(d, "attributes", key) {
+		return nil
+	}
+
+	ref := float32(value)
+	return &ref
+}
+
+// isConfigured reports whether a field of an attributes or relationships block is set in the configuration, which
+// tells apart zero values from attributes that are not set at all. When no configuration is available it falls back
+// to the value being non-zero.
+func isConfigured(d *schema.ResourceData, block string, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		_, ok := d.GetOk(blockKey(d, block, key))
+		return ok
+	}
+
+	if !configHasBlock(config, block) {
+		return config.Type().IsObjectType() && config.Type().HasAttribute(key) && !config.GetAttr(key).IsNull()
+	}
+	return configHasKey(config, block, key)
+}
+
+func configHasKey(config cty.Value, block string, key string) bool {
+	if !configHasBlock(config, block) {
+		return false
+	}
+
+	item := config.GetAttr(block).Index(cty.NumberIntVal(0))
+	if !item.Type().IsObjectType() || !item.Type().HasAttribute(key) {
+		return false
+	}
+
+	return !item.GetAttr(key).IsNull()
+}
@@ -1,9 +1,14 @@
 package commercelayer
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/incentro-dc/go-commercelayer-sdk/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -103,3 +108,148 @@ func TestNestedMapFilledVal(t *testing.T) {
 		map[string]interface{}{"hello": "world"},
 	}))
 }
+
+func TestSetEnabledDisabledAt(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{"name": "manual"}},
+	})
+
+	assert.NoError(t, setEnabled(d, "2024-10-24T16:07:17.169Z"))
+	assert.False(t, d.Get("attributes.0.enabled").(bool))
+	assert.Equal(t, "manual", d.Get("attributes.0.name"))
+
+	assert.NoError(t, setEnabled(d, nil))
+	assert.True(t, d.Get("attributes.0.enabled").(bool))
+}
+
+func TestConfiguredInt32RefNotConfigured(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceShippingMethod().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{"name": "shipping"}},
+	})
+
+	assert.Nil(t, configuredInt32Ref(d, "free_over_amount_cents"))
+	assert.Nil(t, configuredFloat32Ref(d, "min_weight"))
+}
+
+func TestConfiguredInt32RefConfigured(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceShippingMethod().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{
+			"name":                   "shipping",
+			"free_over_amount_cents": 1000,
+			"min_weight":             0.5,
+		}},
+	})
+
+	assert.Equal(t, int32(1000), *configuredInt32Ref(d, "free_over_amount_cents"))
+	assert.Equal(t, float32(0.5), *configuredFloat32Ref(d, "min_weight"))
+}
+
+func TestConfigHasKey(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"attributes": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"free_over_amount_cents": cty.NumberIntVal(0),
+			"min_weight":             cty.NullVal(cty.Number),
+		})}),
+	})
+
+	assert.True(t, configHasKey(config, "attributes", "free_over_amount_cents"))
+	assert.False(t, configHasKey(config, "attributes", "min_weight"))
+	assert.False(t, configHasKey(config, "attributes", "max_weight"))
+	assert.False(t, configHasKey(config, "relationships", "market_id"))
+}
+
+func TestConfigHasKeyEmptyBlock(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"relationships": cty.ListValEmpty(cty.Object(map[string]cty.Type{"market_id": cty.String})),
+	})
+
+	assert.False(t, configHasKey(config, "relationships", "market_id"))
+}
+
+func TestChangedAttribute(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceShippingMethod().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{"name": "shipping"}},
+	})
+
+	assert.Equal(t, "shipping", *changedAttribute(d, "name", stringRef("shipping")).(*string))
+	assert.Nil(t, changedAttribute(d, "reference", stringRef("reference")))
+
+	d.MarkNewResource()
+	assert.Equal(t, "reference", *changedAttribute(d, "reference", stringRef("reference")).(*string))
+}
+
+func TestMetadataRefMetadataJson(t *testing.T) {
+	metadata := metadataRef(map[string]any{
+		"metadata":      map[string]interface{}{},
+		"metadata_json": `{"erp":{"id":42}}`,
+	})
+
+	assert.Equal(t, map[string]interface{}{"erp": map[string]interface{}{"id": json.Number("42")}}, metadata)
+}
+
+func TestMetadataRefMetadata(t *testing.T) {
+	metadata := metadataRef(map[string]any{
+		"metadata":      map[string]interface{}{"foo": "bar"},
+		"metadata_json": "",
+	})
+
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, metadata)
+}
+
+func TestSetMetadataJson(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{
+			"name":          "manual",
+			"metadata_json": `{"erp":{"id":42},"removed":true}`,
+		}},
+	})
+
+	remote := map[string]any{
+		"erp":      map[string]any{"id": json.Number("43")},
+		"external": "value",
+	}
+
+	assert.NoError(t, setMetadataJson(d, remote))
+	assert.Equal(t, `{"erp":{"id":43}}`, d.Get("attributes.0.metadata_json"))
+	assert.Equal(t, "manual", d.Get("attributes.0.name"))
+}
+
+func TestSetMetadataJsonNotConfigured(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceManualGateway().Schema, map[string]interface{}{
+		"attributes": []interface{}{map[string]interface{}{"name": "manual"}},
+	})
+
+	assert.NoError(t, setMetadataJson(d, map[string]any{"external": "value"}))
+	assert.Equal(t, "", d.Get("attributes.0.metadata_json"))
+}
+
+func TestMetadataJsonRemoteKeys(t *testing.T) {
+	f := newFakeApi(t)
+	c := f.client(t)
+	ctx := context.Background()
+	r := resourceManualGateway()
+	id := f.add(manualGatewaysType, map[string]any{
+		"name":     "manual",
+		"metadata": map[string]any{"erp": 42, "external": "value"},
+	}, nil)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":          "manual",
+		"metadata_json": `{"erp":41}`,
+	})
+	d.SetId(id)
+
+	// The remote value of a configured key is reported, the key another system added is not.
+	require.False(t, r.ReadContext(ctx, d, c).HasError())
+	assert.Equal(t, `{"erp":42}`, d.Get("metadata_json"))
+
+	// Updates keep the key another system added.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":          "manual",
+		"metadata_json": `{"erp":43}`,
+	})
+	d.SetId(id)
+	require.False(t, r.UpdateContext(ctx, d, c).HasError())
+	assert.Equal(t, `{"erp":43}`, d.Get("metadata_json"))
+	assert.Equal(t, map[string]any{"erp": json.Number("43"), "external": "value"}, f.resources[id].attributes["metadata"])
+}
@@ -1,10 +1,19 @@
 package commercelayer
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/ladydascalie/currency"
+	"net/mail"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var currencyCodeValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
@@ -12,6 +21,178 @@ var currencyCodeValidation = func(i interface{}, path cty.Path) diag.Diagnostics
 	return diagErr(err)
 }
 
+var emailValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	address, err := mail.ParseAddress(i.(string))
+	if err != nil || address.Address != i.(string) {
+		return diag.Errorf("Invalid email address provided: %s", i.(string))
+	}
+	return nil
+}
+
+// normalizeEmail lowercases email addresses, as Commerce Layer stores them case-insensitively.
+func normalizeEmail(i interface{}) string {
+	return strings.ToLower(i.(string))
+}
+
+var rfc3339Validation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	_, err := time.Parse(time.RFC3339, i.(string))
+	if err != nil {
+		return diag.Errorf("Invalid RFC 3339 timestamp provided: %s", i.(string))
+	}
+	return nil
+}
+
+var durationValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	duration, err := time.ParseDuration(i.(string))
+	if err != nil || duration <= 0 {
+		return diag.Errorf("Invalid duration provided: %s", i.(string))
+	}
+	return nil
+}
+
+var jsonObjectValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	var object map[string]any
+	err := json.Unmarshal([]byte(i.(string)), &object)
+	if err != nil || object == nil {
+		return diag.Errorf("Invalid JSON object provided: %s", i.(string))
+	}
+	return nil
+}
+
+// normalizeJson rewrites a JSON document with sorted keys and without whitespace, so formatting differences between
+// the configuration and the state don't show up as changes.
+func normalizeJson(i interface{}) string {
+	var document any
+	err := decodeJson([]byte(i.(string)), &document)
+	if err != nil {
+		return i.(string)
+	}
+
+	normalized, err := json.Marshal(document)
+	if err != nil {
+		return i.(string)
+	}
+	return string(normalized)
+}
+
+var shippingZoneRegexValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	err := validateShippingZoneRegex(i.(string))
+	if err != nil {
+		return diag.Errorf("Invalid regex provided: %s. %s", i.(string), err)
+	}
+	return nil
+}
+
+// validateShippingZoneRegex checks that a shipping zone regex is in the subset of the regex syntax that Ruby, which
+// Commerce Layer evaluates the regexes with, and Go share. Lookarounds, backreferences and the like are Ruby only and
+// fail to compile, the syntax Go adds on top of Ruby is rejected by checkRubyRegexSyntax.
+func validateShippingZoneRegex(pattern string) error {
+	_, err := regexp.Compile(pattern)
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		switch syntaxErr.Code {
+		case syntax.ErrInvalidPerlOp, syntax.ErrInvalidRepeatOp:
+			return fmt.Errorf("%s: lookarounds, atomic groups and possessive quantifiers are not supported", err)
+		case syntax.ErrInvalidEscape:
+			if rubyRegexBackreference.MatchString(syntaxErr.Expr) {
+				return fmt.Errorf("%s: backreferences are not supported", err)
+			}
+			return fmt.Errorf("%s: the %s escape is not supported, only the escapes that Ruby and Go share are", err,
+				syntaxErr.Expr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return checkRubyRegexSyntax(pattern)
+}
+
+// rubyRegexBackreference matches the escapes that start a numbered or named backreference, like \1 or \k<name>.
+var rubyRegexBackreference = regexp.MustCompile(`^\\([1-9]|k)`)
+
+// rubyRegexFlagGroup matches the start of a group that sets flags, like (?i) or (?i:...).
+var rubyRegexFlagGroup = regexp.MustCompile(`^\(\?([a-zA-Z-]*)[:)]`)
+
+// checkRubyRegexSyntax scans a regex for the syntax that compiles in Go, but is rejected or interpreted differently
+// by Ruby: \Q...\E quoting, (?P<name>) groups and the s, m and U flags.
+func checkRubyRegexSyntax(pattern string) error {
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			if pattern[i+1] == 'Q' || pattern[i+1] == 'E' {
+				return fmt.Errorf("\\%c quoting is not supported by Ruby, escape the characters instead",
+					pattern[i+1])
+			}
+			i++
+		case inClass:
+			if pattern[i] == ']' {
+				inClass = false
+			}
+		case pattern[i] == '[':
+			inClass = true
+			// A ] right after the opening bracket, or after its negation, is a literal
+			if strings.HasPrefix(pattern[i+1:], "^]") {
+				i += 2
+			} else if strings.HasPrefix(pattern[i+1:], "]") {
+				i++
+			}
+		case strings.HasPrefix(pattern[i:], "(?P<"):
+			return fmt.Errorf("(?P<name>) groups are not supported by Ruby, use (?<name>) instead")
+		case rubyRegexFlagGroup.MatchString(pattern[i:]):
+			flags := rubyRegexFlagGroup.FindStringSubmatch(pattern[i:])[1]
+			if strings.ContainsAny(flags, "smU") {
+				return fmt.Errorf("the s, m and U flags are not supported by Ruby or mean something else there, " +
+					"only the i flag is supported")
+			}
+		}
+	}
+	return nil
+}
+
+// webhookTopicFormat matches a resource/event pair, like orders.place.
+var webhookTopicFormat = regexp.MustCompile(`^[a-z_]+\.[a-z_]+$`)
+
+// webhookTopicValidation rejects topics that aren't in the catalogue. Topics of a resource the catalogue doesn't know
+// only get a warning, as Commerce Layer adds resources this provider version doesn't know yet, unless they are close
+// enough to a known topic to be a typo.
+var webhookTopicValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	topic := i.(string)
+	topics := getWebhookTopics()
+	if !webhookTopicFormat.MatchString(topic) {
+		return diag.Errorf("Invalid webhook topic provided: %s%s. Must be a resource/event pair, like orders.place",
+			topic, didYouMean(topic, topics))
+	}
+
+	index := sort.SearchStrings(topics, topic)
+	if index < len(topics) && topics[index] == topic {
+		return nil
+	}
+
+	resource, _, _ := strings.Cut(topic, ".")
+	if topicResource, ok := webhookTopics[resource]; ok {
+		var resourceTopics []string
+		for _, event := range topicResource.events {
+			resourceTopics = append(resourceTopics, resource+"."+event)
+		}
+		return diag.Errorf("Invalid webhook topic provided: %s%s. Must be one of %s", topic,
+			didYouMean(topic, resourceTopics), strings.Join(resourceTopics, ", "))
+	}
+
+	if suggestion := didYouMean(topic, topics); suggestion != "" {
+		return diag.Errorf("Invalid webhook topic provided: %s%s. Must be one of %s", topic, suggestion,
+			strings.Join(topics, ", "))
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unknown webhook topic: %s", topic),
+			Detail: fmt.Sprintf("The %s resource is not one this version of the provider knows about, so Commerce "+
+				"Layer may reject the topic. Known topics are %s", resource, strings.Join(topics, ", ")),
+		},
+	}
+}
+
 func getInventoryModelStrategies() []string {
 	return []string{
 		"no_split",
@@ -35,12 +216,14 @@ var inventoryModelStrategyValidation = func(i interface{}, path cty.Path) diag.D
 func getPaymentSources() []string {
 	return []string{
 		"AdyenPayment",
+		"AxervePayment",
 		"BraintreePayment",
 		"CheckoutComPayment",
 		"CreditCard",
 		"ExternalPayment",
 		"KlarnaPayment",
 		"PaypalPayment",
+		"SatispayPayment",
 		"StripePayment",
 		"WireTransfer",
 	}
@@ -55,3 +238,131 @@ var paymentSourceValidation = func(i interface{}, path cty.Path) diag.Diagnostic
 	return diag.Errorf("Invalid payment source provided: %s. Must be one of %s",
 		i.(string), strings.Join(getPaymentSources(), ", "))
 }
+
+func getSubscriptionModelStrategies() []string {
+	return []string{
+		"by_frequency",
+		"by_line_items",
+	}
+}
+
+var subscriptionModelStrategyValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	for _, s := range getSubscriptionModelStrategies() {
+		if s == i.(string) {
+			return nil
+		}
+	}
+	return diag.Errorf("Invalid subscription model strategy provided: %s. Must be one of %s",
+		i.(string), strings.Join(getSubscriptionModelStrategies(), ", "))
+}
+
+func getSubscriptionModelFrequencies() []string {
+	return []string{
+		"hourly",
+		"daily",
+		"weekly",
+		"monthly",
+		"two-month",
+		"three-month",
+		"four-month",
+		"six-month",
+		"yearly",
+	}
+}
+
+var subscriptionModelFrequencyValidation = func(i interface{}, path cty.Path) diag.Diagnostics {
+	for _, s := range getSubscriptionModelFrequencies() {
+		if s == i.(string) {
+			return nil
+		}
+	}
+
+	if err := validateCronExpression(i.(string)); err != nil {
+		return diag.Errorf("Invalid subscription model frequency provided: %s. Must be one of %s or a valid "+
+			"crontab expression (%s)", i.(string), strings.Join(getSubscriptionModelFrequencies(), ", "), err)
+	}
+
+	return nil
+}
+
+// cronFieldBounds holds the allowed range of values for each of the five crontab fields: minute, hour, day of
+// month, month and day of week.
+var cronFieldBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// validateCronExpression checks that the given expression is a five field crontab expression. Commerce Layer does
+// not support frequencies smaller than an hour, so the minute field must be a single fixed value.
+func validateCronExpression(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFieldBounds) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFieldBounds), len(fields))
+	}
+
+	if _, err := parseCronValue(fields[0], cronFieldBounds[0]); err != nil {
+		return fmt.Errorf("minute field must be a single value, min unit is hour: %s", err)
+	}
+
+	for idx, field := range fields[1:] {
+		if err := validateCronField(field, cronFieldBounds[idx+1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateCronField(field string, bounds [2]int) error {
+	for _, item := range strings.Split(field, ",") {
+		rangeExpr, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q in %q", step, field)
+			}
+		}
+
+		if rangeExpr == "*" {
+			continue
+		}
+
+		start, end, isRange := strings.Cut(rangeExpr, "-")
+		from, err := parseCronValue(start, bounds)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+
+		to, err := parseCronValue(end, bounds)
+		if err != nil {
+			return err
+		}
+		if from > to {
+			return fmt.Errorf("invalid range %q in %q", rangeExpr, field)
+		}
+	}
+
+	return nil
+}
+
+func parseCronValue(value string, bounds [2]int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if n < bounds[0] || n > bounds[1] {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, bounds[0], bounds[1])
+	}
+	return n, nil
+}
+
+// validateAutoCaptureMaxAmount checks that a maximum auto capture amount is only configured when auto_capture is true,
+// as Commerce Layer would otherwise silently ignore it. Whether the payment method itself is offered is set by enabled.
+func validateAutoCaptureMaxAmount(autoCapture bool, maxAmountCents int) error {
+	if maxAmountCents != 0 && !autoCapture {
+		return fmt.Errorf("auto_capture_max_amount_cents can only be set when auto_capture is true")
+	}
+	if maxAmountCents < 0 {
+		return fmt.Errorf("auto_capture_max_amount_cents must be a positive amount, got: %d", maxAmountCents)
+	}
+	return nil
+}
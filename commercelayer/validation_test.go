@@ -1,6 +1,10 @@
 package commercelayer
 
 import (
+	"context"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -15,6 +19,22 @@ func TestCurrencyCodeValidationOK(t *testing.T) {
 	assert.False(t, diag.HasError())
 }
 
+func TestEmailValidationErr(t *testing.T) {
+	for _, email := range []string{"", "foobar", "Foo <foo@example.com>", "foo@"} {
+		diag := emailValidation(email, nil)
+		assert.True(t, diag.HasError(), email)
+	}
+}
+
+func TestEmailValidationOK(t *testing.T) {
+	diag := emailValidation("John.Doe@Example.com", nil)
+	assert.False(t, diag.HasError())
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "john.doe@example.com", normalizeEmail("John.Doe@Example.COM"))
+}
+
 func TestPaymentSourceValidationError(t *testing.T) {
 	diag := paymentSourceValidation("Adyen", nil)
 	assert.True(t, diag.HasError())
@@ -24,3 +44,164 @@ func TestPaymentSourceValidationOK(t *testing.T) {
 	diag := paymentSourceValidation("BraintreePayment", nil)
 	assert.False(t, diag.HasError())
 }
+
+func TestPaymentSourceValidationNewerGatewaysOK(t *testing.T) {
+	for _, source := range []string{"AxervePayment", "SatispayPayment"} {
+		diag := paymentSourceValidation(source, nil)
+		assert.False(t, diag.HasError(), source)
+	}
+}
+
+func TestSubscriptionModelStrategyValidationErr(t *testing.T) {
+	diag := subscriptionModelStrategyValidation("by_weather", nil)
+	assert.True(t, diag.HasError())
+}
+
+func TestSubscriptionModelStrategyValidationOK(t *testing.T) {
+	diag := subscriptionModelStrategyValidation("by_line_items", nil)
+	assert.False(t, diag.HasError())
+}
+
+func TestSubscriptionModelFrequencyValidationOK(t *testing.T) {
+	for _, frequency := range []string{"monthly", "six-month", "0 8 * * 1-5", "30 */6 1,15 * *"} {
+		diag := subscriptionModelFrequencyValidation(frequency, nil)
+		assert.False(t, diag.HasError(), frequency)
+	}
+}
+
+func TestSubscriptionModelFrequencyValidationErr(t *testing.T) {
+	for _, frequency := range []string{"fortnightly", "* * * * *", "*/5 * * * *", "0 24 * * *", "0 8 * *", "0 8 5-1 * *"} {
+		diag := subscriptionModelFrequencyValidation(frequency, nil)
+		assert.True(t, diag.HasError(), frequency)
+	}
+}
+
+func TestValidateAutoCaptureMaxAmountOK(t *testing.T) {
+	assert.NoError(t, validateAutoCaptureMaxAmount(false, 0))
+	assert.NoError(t, validateAutoCaptureMaxAmount(true, 0))
+	assert.NoError(t, validateAutoCaptureMaxAmount(true, 10000))
+}
+
+func TestValidateAutoCaptureMaxAmountErr(t *testing.T) {
+	assert.Error(t, validateAutoCaptureMaxAmount(false, 10000))
+	assert.Error(t, validateAutoCaptureMaxAmount(true, -1))
+}
+
+// testPlanPaymentMethod plans a new payment method with a maximum auto capture amount, and returns the error of the
+// plan.
+func testPlanPaymentMethod(autoCapture cty.Value) error {
+	r := resourcePaymentMethod()
+	coreSchema := r.CoreConfigSchema()
+	config := testCtyValue(coreSchema.ImpliedType(), map[string]any{
+		"currency_code":                 "EUR",
+		"price_amount_cents":            cty.NumberIntVal(1000),
+		"auto_capture":                  autoCapture,
+		"auto_capture_max_amount_cents": cty.NumberIntVal(10000),
+		"payment_gateway_id":            "vZbKkyAbcd",
+	})
+
+	_, err := r.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigShimmed(config, coreSchema), nil)
+	return err
+}
+
+func TestPaymentMethodAutoCaptureMaxAmount(t *testing.T) {
+	assert.NoError(t, testPlanPaymentMethod(cty.True))
+	assert.ErrorContains(t, testPlanPaymentMethod(cty.False),
+		"auto_capture_max_amount_cents can only be set when auto_capture is true")
+	assert.NoError(t, testPlanPaymentMethod(cty.UnknownVal(cty.Bool)))
+}
+
+func TestRfc3339ValidationOK(t *testing.T) {
+	assert.Nil(t, rfc3339Validation("2024-11-29T00:00:00Z", nil))
+	assert.Nil(t, rfc3339Validation("2024-12-02T23:59:59+01:00", nil))
+}
+
+func TestRfc3339ValidationErr(t *testing.T) {
+	assert.True(t, rfc3339Validation("2024-11-29", nil).HasError())
+	assert.True(t, rfc3339Validation("black friday", nil).HasError())
+}
+
+func TestJsonObjectValidationOK(t *testing.T) {
+	assert.Nil(t, jsonObjectValidation(`{}`, nil))
+	assert.Nil(t, jsonObjectValidation(`{"erp": {"id": 12345678901234567890, "tags": ["a", "b"]}}`, nil))
+}
+
+func TestJsonObjectValidationErr(t *testing.T) {
+	assert.True(t, jsonObjectValidation(`{"erp":`, nil).HasError())
+	assert.True(t, jsonObjectValidation(`["erp"]`, nil).HasError())
+	assert.True(t, jsonObjectValidation(`null`, nil).HasError())
+}
+
+func TestNormalizeJson(t *testing.T) {
+	assert.Equal(t, `{"a":1.50,"b":{"c":[1,2],"d":12345678901234567890}}`,
+		normalizeJson("{\n  \"b\": {\"d\": 12345678901234567890, \"c\": [1, 2]},\n  \"a\": 1.50\n}"))
+}
+
+func TestNormalizeJsonInvalid(t *testing.T) {
+	assert.Equal(t, `{"a":`, normalizeJson(`{"a":`))
+}
+
+func TestDurationValidationOK(t *testing.T) {
+	assert.Nil(t, durationValidation("500ms", nil))
+	assert.Nil(t, durationValidation("1m30s", nil))
+}
+
+func TestDurationValidationErr(t *testing.T) {
+	assert.True(t, durationValidation("10", nil).HasError())
+	assert.True(t, durationValidation("0s", nil).HasError())
+}
+
+func TestShippingZoneRegexValidationOK(t *testing.T) {
+	assert.Nil(t, shippingZoneRegexValidation("^(NL|BE)$", nil))
+	assert.Nil(t, shippingZoneRegexValidation("(?i)^[a-z]{2}$", nil))
+	assert.Nil(t, shippingZoneRegexValidation("(?<sum>[0-9]+)", nil))
+	assert.Nil(t, shippingZoneRegexValidation("[(?s)]", nil))
+}
+
+func TestShippingZoneRegexValidationErr(t *testing.T) {
+	assert.True(t, shippingZoneRegexValidation("(NL", nil).HasError())
+	assert.True(t, shippingZoneRegexValidation("^(?!NL)", nil).HasError())
+	assert.True(t, shippingZoneRegexValidation(`(a)\1`, nil).HasError())
+	assert.True(t, shippingZoneRegexValidation(`\Q.\E`, nil).HasError())
+	assert.True(t, shippingZoneRegexValidation("(?P<code>NL)", nil).HasError())
+	assert.True(t, shippingZoneRegexValidation("(?s).", nil).HasError())
+	assert.True(t, shippingZoneRegexValidation("(?im:^NL$)", nil).HasError())
+}
+
+func TestValidateShippingZoneRegexHint(t *testing.T) {
+	assert.ErrorContains(t, validateShippingZoneRegex("^(?!NL)"), "lookarounds")
+	assert.NotContains(t, validateShippingZoneRegex("(NL").Error(), "lookarounds")
+	assert.ErrorContains(t, validateShippingZoneRegex(`(a)\1`), "backreferences are not supported")
+	assert.ErrorContains(t, validateShippingZoneRegex(`(?<a>a)\k<a>`), "backreferences are not supported")
+	assert.ErrorContains(t, validateShippingZoneRegex(`^NL\Z`),
+		"the \\Z escape is not supported, only the escapes that Ruby and Go share are")
+	assert.NotContains(t, validateShippingZoneRegex(`\G[0-9]`).Error(), "lookarounds")
+}
+
+func TestWebhookTopicValidationOK(t *testing.T) {
+	for _, topic := range []string{"orders.place", "shipments.ready_to_ship", "tags.update"} {
+		diag := webhookTopicValidation(topic, nil)
+		assert.False(t, diag.HasError(), topic)
+	}
+}
+
+func TestWebhookTopicValidationErr(t *testing.T) {
+	for _, topic := range []string{"", "orders", "orders.place.now", "Orders.place", "orders.shipped", "order.place",
+		"orders.teleport", "custmers.create", "order.plcaed"} {
+		diag := webhookTopicValidation(topic, nil)
+		assert.True(t, diag.HasError(), topic)
+	}
+	assert.Contains(t, webhookTopicValidation("orders", nil)[0].Summary, "Must be a resource/event pair")
+	assert.Contains(t, webhookTopicValidation("order.place", nil)[0].Summary, "(did you mean orders.place?)")
+	assert.Contains(t, webhookTopicValidation("orders.plcaed", nil)[0].Summary,
+		"Invalid webhook topic provided: orders.plcaed (did you mean orders.place?). Must be one of orders.")
+}
+
+func TestWebhookTopicValidationUnknown(t *testing.T) {
+	diags := webhookTopicValidation("loyalty_points.earn", nil)
+	assert.False(t, diags.HasError())
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "Unknown webhook topic: loyalty_points.earn", diags[0].Summary)
+	}
+}
@@ -0,0 +1,94 @@
+package commercelayer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const defaultWaitTimeout = 5 * time.Minute
+
+// resourceTimeouts returns the timeouts every resource supports. Creates wait until the new resource can be read and
+// deletes wait until it is gone, as Commerce Layer is eventually consistent.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultWaitTimeout),
+		Delete: schema.DefaultTimeout(defaultWaitTimeout),
+	}
+}
+
+// waitForCreated polls a newly created resource until Commerce Layer returns it, so that resources depending on it
+// don't fail because it can't be found yet.
+func waitForCreated(ctx context.Context, c *apiClient, d *schema.ResourceData, resourceType string) error {
+	return c.poll(ctx, d.Timeout(schema.TimeoutCreate), func() (bool, error) {
+		resp, body, err := sendJsonApiRequest(ctx, c, http.MethodGet, resourceType, d.Id(), nil)
+		if err != nil {
+			return false, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return true, nil
+		case http.StatusNotFound:
+			return false, nil
+		default:
+			return false, fmt.Errorf("%s: %s", resp.Status, string(body))
+		}
+	})
+}
+
+// waitForDeleted polls a deleted resource until Commerce Layer no longer returns it.
+func waitForDeleted(ctx context.Context, c *apiClient, d *schema.ResourceData, resourceType string) error {
+	return waitForRemoval(ctx, c, d.Timeout(schema.TimeoutDelete), resourceType, d.Id())
+}
+
+// waitForRemoval polls a resource by its id until Commerce Layer no longer returns it.
+func waitForRemoval(ctx context.Context, c *apiClient, timeout time.Duration, resourceType string, id string) error {
+	return c.poll(ctx, timeout, func() (bool, error) {
+		resp, body, err := sendJsonApiRequest(ctx, c, http.MethodGet, resourceType, id, nil)
+		if err != nil {
+			return false, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return true, nil
+		case http.StatusOK:
+			return false, nil
+		default:
+			return false, fmt.Errorf("%s: %s", resp.Status, string(body))
+		}
+	})
+}
+
+// poll calls check until it is done, doubling the interval between calls from the poll interval up to the maximum
+// poll interval. It gives up once the timeout expires.
+func (c *apiClient) poll(ctx context.Context, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	interval := c.pollInterval
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return fmt.Errorf("timeout while waiting for resource after %s", timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, c.maxPollInterval)
+	}
+}
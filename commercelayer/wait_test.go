@@ -0,0 +1,107 @@
+package commercelayer
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testApiClient(serverUrl string) *apiClient {
+	return &apiClient{
+		APIClient: commercelayer.NewAPIClient(&commercelayer.Configuration{
+			HTTPClient: http.DefaultClient,
+			Servers: []commercelayer.ServerConfiguration{
+				{URL: serverUrl},
+			},
+		}),
+		pollInterval:    time.Millisecond,
+		maxPollInterval: 4 * time.Millisecond,
+	}
+}
+
+func TestPollDone(t *testing.T) {
+	c := testApiClient("")
+
+	calls := 0
+	err := c.poll(context.Background(), time.Second, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestPollError(t *testing.T) {
+	c := testApiClient("")
+
+	err := c.poll(context.Background(), time.Second, func() (bool, error) {
+		return false, errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed")
+}
+
+func TestPollTimeout(t *testing.T) {
+	c := testApiClient("")
+
+	err := c.poll(context.Background(), 10*time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	assert.Error(t, err)
+}
+
+func TestWaitForCreated(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/markets/xYZkjABcde", r.URL.Path)
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"xYZkjABcde"}}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{})
+	d.SetId("xYZkjABcde")
+
+	assert.NoError(t, waitForCreated(context.Background(), testApiClient(server.URL), d, marketType))
+	assert.Equal(t, 3, requests)
+}
+
+func TestWaitForDeleted(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 2 {
+			_, _ = w.Write([]byte(`{"data":{"id":"xYZkjABcde"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{})
+	d.SetId("xYZkjABcde")
+
+	assert.NoError(t, waitForDeleted(context.Background(), testApiClient(server.URL), d, marketType))
+	assert.Equal(t, 2, requests)
+}
+
+func TestWaitForDeletedUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceMarket().Schema, map[string]interface{}{})
+	d.SetId("xYZkjABcde")
+
+	assert.Error(t, waitForDeleted(context.Background(), testApiClient(server.URL), d, marketType))
+}
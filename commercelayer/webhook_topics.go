@@ -0,0 +1,241 @@
+package commercelayer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	commercelayer "github.com/incentro-dc/go-commercelayer-sdk/api"
+)
+
+// webhookTopicResource describes a resource webhooks can subscribe to: the events it triggers and the relationships
+// that can be included in the webhook body. The relationships are read from the model of the generated SDK, which
+// leaves them nil for the resources it has no relationships model for.
+type webhookTopicResource struct {
+	events        []string
+	relationships any
+}
+
+var promotionEvents = []string{"create", "destroy", "update"}
+var jobEvents = []string{"create", "destroy", "start", "complete", "interrupt"}
+var copyEvents = []string{"create", "destroy", "start", "complete", "fail"}
+
+// webhookTopics is the catalogue of resource/event pairs Commerce Layer triggers webhooks for.
+var webhookTopics = map[string]webhookTopicResource{
+	"authorizations": {
+		events:        []string{"create", "failed"},
+		relationships: commercelayer.AuthorizationDataRelationships{},
+	},
+	"buy_x_pay_y_promotions": {
+		events:        promotionEvents,
+		relationships: commercelayer.BuyXPayYPromotionDataRelationships{},
+	},
+	"captures": {
+		events:        []string{"create", "failed"},
+		relationships: commercelayer.CaptureDataRelationships{},
+	},
+	"cleanups": {
+		events:        jobEvents,
+		relationships: commercelayer.CleanupDataRelationships{},
+	},
+	"coupons": {
+		events:        promotionEvents,
+		relationships: commercelayer.CouponDataRelationships{},
+	},
+	"customer_password_resets": {
+		events:        []string{"create", "reset_password"},
+		relationships: commercelayer.CustomerPasswordResetDataRelationships{},
+	},
+	"customer_subscriptions": {
+		events:        []string{"create", "destroy"},
+		relationships: commercelayer.CustomerSubscriptionDataRelationships{},
+	},
+	"customers": {
+		events:        []string{"create", "destroy", "update"},
+		relationships: commercelayer.CustomerDataRelationships{},
+	},
+	"exports": {
+		events:        jobEvents,
+		relationships: commercelayer.ExportDataRelationships{},
+	},
+	"external_promotions": {
+		events: promotionEvents,
+	},
+	"fixed_amount_promotions": {
+		events: promotionEvents,
+	},
+	"fixed_price_promotions": {
+		events: promotionEvents,
+	},
+	"flex_promotions": {
+		events:        promotionEvents,
+		relationships: commercelayer.FlexPromotionDataRelationships{},
+	},
+	"free_gift_promotions": {
+		events: promotionEvents,
+	},
+	"free_shipping_promotions": {
+		events:        promotionEvents,
+		relationships: commercelayer.FreeShippingPromotionDataRelationships{},
+	},
+	"gift_cards": {
+		events:        []string{"create", "purchase", "activate", "deactivate", "use", "destroy"},
+		relationships: commercelayer.GiftCardDataRelationships{},
+	},
+	"imports": {
+		events: jobEvents,
+	},
+	"in_stock_subscriptions": {
+		events:        []string{"create", "activate", "deactivate", "notify", "destroy"},
+		relationships: commercelayer.InStockSubscriptionDataRelationships{},
+	},
+	"line_items": {
+		events:        []string{"create", "destroy", "update"},
+		relationships: commercelayer.LineItemDataRelationships{},
+	},
+	"order_copies": {
+		events:        copyEvents,
+		relationships: commercelayer.OrderCopyDataRelationships{},
+	},
+	"order_subscriptions": {
+		events:        []string{"create", "activate", "deactivate", "cancel", "destroy"},
+		relationships: commercelayer.OrderSubscriptionDataRelationships{},
+	},
+	"orders": {
+		events: []string{"create", "destroy", "place", "approve", "cancel", "authorize", "fulfill",
+			"start_editing", "stop_editing"},
+		relationships: commercelayer.OrderDataRelationships{},
+	},
+	"percentage_discount_promotions": {
+		events: promotionEvents,
+	},
+	"recurring_order_copies": {
+		events: copyEvents,
+		// The generated SDK models the relationships of recurring order copies with those of order copies
+		relationships: commercelayer.OrderCopyDataRelationships{},
+	},
+	"refunds": {
+		events:        []string{"create", "failed"},
+		relationships: commercelayer.RefundDataRelationships{},
+	},
+	"returns": {
+		events: []string{"create", "destroy", "request", "approve", "cancel", "ship", "reject", "receive",
+			"restock", "archive", "unarchive"},
+		relationships: commercelayer.ReturnDataRelationships{},
+	},
+	"shipments": {
+		events: []string{"create", "upcoming", "on_hold", "picking", "packing", "ready_to_ship", "ship",
+			"deliver"},
+		relationships: commercelayer.ShipmentDataRelationships{},
+	},
+	"skus": {
+		events:        []string{"create", "destroy", "update"},
+		relationships: commercelayer.SkuDataRelationships{},
+	},
+	"stock_items": {
+		events:        []string{"create", "destroy", "update"},
+		relationships: commercelayer.StockItemDataRelationships{},
+	},
+	"stock_transfers": {
+		events: []string{"create", "destroy", "upcoming", "on_hold", "picking", "in_transit", "complete",
+			"cancel"},
+		relationships: commercelayer.StockTransferDataRelationships{},
+	},
+	"tags": {
+		events: []string{"create", "destroy", "update"},
+	},
+	"voids": {
+		events:        []string{"create", "failed"},
+		relationships: commercelayer.VoidDataRelationships{},
+	},
+}
+
+// getWebhookTopics returns all topics of the catalogue, sorted.
+func getWebhookTopics() []string {
+	var topics []string
+	for resource, topicResource := range webhookTopics {
+		for _, event := range topicResource.events {
+			topics = append(topics, resource+"."+event)
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// webhookTopicRelationships returns the relationships that can be included in the body of a webhook for a topic, or
+// nil when they are not known.
+func webhookTopicRelationships(topic string) []string {
+	resource, _, _ := strings.Cut(topic, ".")
+	topicResource, ok := webhookTopics[resource]
+	if !ok || topicResource.relationships == nil {
+		return nil
+	}
+
+	var relationships []string
+	t := reflect.TypeOf(topicResource.relationships)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" {
+			relationships = append(relationships, name)
+		}
+	}
+	sort.Strings(relationships)
+	return relationships
+}
+
+// validateIncludeResources checks that the resources included in the body of a webhook are relationships of the
+// resource of its topic. Nested relationships, like line_items.item, are checked on their first relationship only.
+func validateIncludeResources(topic string, includeResources []string) error {
+	relationships := webhookTopicRelationships(topic)
+	if relationships == nil {
+		return nil
+	}
+
+	for _, includeResource := range includeResources {
+		relationship, _, _ := strings.Cut(includeResource, ".")
+		index := sort.SearchStrings(relationships, relationship)
+		if index == len(relationships) || relationships[index] != relationship {
+			return fmt.Errorf("%s is not a relationship of the %s topic%s. Must be one of %s", includeResource,
+				topic, didYouMean(relationship, relationships), strings.Join(relationships, ", "))
+		}
+	}
+
+	return nil
+}
+
+// didYouMean suggests the closest of the candidates for a misspelled value, if any is close enough.
+func didYouMean(value string, candidates []string) string {
+	best, bestDistance := "", len(value)/3+1
+	for _, candidate := range candidates {
+		if distance := levenshtein(value, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", best)
+}
+
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,52 @@
+package commercelayer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetWebhookTopicsSorted(t *testing.T) {
+	topics := getWebhookTopics()
+	assert.IsIncreasing(t, topics)
+	assert.Contains(t, topics, "orders.place")
+	assert.Contains(t, topics, "customer_password_resets.reset_password")
+}
+
+func TestWebhookTopicRelationships(t *testing.T) {
+	relationships := webhookTopicRelationships("orders.place")
+	assert.IsIncreasing(t, relationships)
+	assert.Contains(t, relationships, "customer")
+	assert.Contains(t, relationships, "line_items")
+
+	assert.Equal(t, []string{"events", "order_subscription", "source_order", "target_order"},
+		webhookTopicRelationships("recurring_order_copies.complete"))
+
+	assert.Nil(t, webhookTopicRelationships("tags.create"))
+	assert.Nil(t, webhookTopicRelationships("foo.bar"))
+}
+
+func TestValidateIncludeResourcesOK(t *testing.T) {
+	assert.NoError(t, validateIncludeResources("orders.place", []string{"customer", "line_items.item"}))
+	assert.NoError(t, validateIncludeResources("orders.place", nil))
+	assert.NoError(t, validateIncludeResources("tags.create", []string{"anything"}))
+}
+
+func TestValidateIncludeResourcesErr(t *testing.T) {
+	err := validateIncludeResources("orders.place", []string{"customer", "custmer"})
+	assert.ErrorContains(t, err, "custmer is not a relationship of the orders.place topic (did you mean customer?)")
+
+	err = validateIncludeResources("customers.create", []string{"line_items"})
+	assert.ErrorContains(t, err, "line_items is not a relationship of the customers.create topic. Must be one of")
+}
+
+func TestDidYouMean(t *testing.T) {
+	assert.Equal(t, " (did you mean customer?)", didYouMean("custmer", []string{"customer", "market"}))
+	assert.Equal(t, "", didYouMean("shipping", []string{"customer", "market"}))
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("orders", "orders"))
+	assert.Equal(t, 1, levenshtein("order", "orders"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 6, levenshtein("", "orders"))
+}
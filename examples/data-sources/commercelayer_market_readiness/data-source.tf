@@ -0,0 +1,10 @@
+check "incentro_market_readiness" {
+  data "commercelayer_market_readiness" "incentro_market" {
+    market_id = commercelayer_market.incentro_market.id
+  }
+
+  assert {
+    condition     = data.commercelayer_market_readiness.incentro_market.ready
+    error_message = join("\n", [for problem in data.commercelayer_market_readiness.incentro_market.problems : "${problem.check}: ${problem.message}"])
+  }
+}
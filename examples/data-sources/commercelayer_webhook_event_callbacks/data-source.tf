@@ -0,0 +1,17 @@
+check "incentro_webhook_deliveries" {
+  data "commercelayer_webhook_event_callbacks" "failures" {
+    webhook_id    = commercelayer_webhook.incentro_webhook.id
+    status        = "failure"
+    created_after = timeadd(plantimestamp(), "-24h")
+  }
+
+  assert {
+    condition     = commercelayer_webhook.incentro_webhook.circuit_state == "closed"
+    error_message = "The circuit breaker of the incentro webhook is open."
+  }
+
+  assert {
+    condition     = length(data.commercelayer_webhook_event_callbacks.failures.event_callbacks) < 5
+    error_message = "The incentro webhook failed ${length(data.commercelayer_webhook_event_callbacks.failures.event_callbacks)} times in the last 24 hours, most recently at ${data.commercelayer_webhook_event_callbacks.failures.event_callbacks[0].created_at}."
+  }
+}
@@ -0,0 +1,14 @@
+ephemeral "commercelayer_access_token" "smoke_tests" {
+  scope = "market:id:${commercelayer_market.incentro_market.id}"
+}
+
+resource "terraform_data" "smoke_tests" {
+  triggers_replace = [commercelayer_market.incentro_market.id]
+
+  provisioner "local-exec" {
+    command = "./scripts/smoke-tests.sh"
+    environment = {
+      COMMERCELAYER_ACCESS_TOKEN = ephemeral.commercelayer_access_token.smoke_tests.access_token
+    }
+  }
+}
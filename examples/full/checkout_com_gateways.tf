@@ -1,7 +1,5 @@
 resource "commercelayer_checkout_com_gateway" "incentro_checkout_com_gateway" {
-  attributes {
-    name       = "Incentro CheckoutCom Gateway"
-    secret_key = "xxxx-yyyy-zzzz"
-    public_key = "xxxx-yyyy-zzzz"
-  }
+  name       = "Incentro CheckoutCom Gateway"
+  secret_key = "xxxx-yyyy-zzzz"
+  public_key = "xxxx-yyyy-zzzz"
 }
\ No newline at end of file
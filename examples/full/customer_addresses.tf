@@ -0,0 +1,6 @@
+resource "commercelayer_customer_address" "incentro_customer_address" {
+  customer_email = commercelayer_customer.incentro_customer.email
+
+  customer_id = commercelayer_customer.incentro_customer.id
+  address_id  = commercelayer_address.incentro_address.id
+}
@@ -1,8 +1,6 @@
 resource "commercelayer_customer_group" "incentro_customer_group" {
-  attributes {
-    name = "Incentro Customer Group"
-    metadata = {
-      foo : "bar"
-    }
+  name = "Incentro Customer Group"
+  metadata = {
+    foo : "bar"
   }
 }
\ No newline at end of file
@@ -0,0 +1,9 @@
+resource "commercelayer_customer" "incentro_customer" {
+  email    = "b2b-buyer@incentro.com"
+  password = var.customer_password
+  metadata = {
+    foo : "bar"
+  }
+
+  customer_group_id = commercelayer_customer_group.incentro_customer_group.id
+}
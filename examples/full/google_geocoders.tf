@@ -1,9 +1,7 @@
 resource "commercelayer_google_geocoder" "incentro_google_geocoder" {
-  attributes {
-    name    = "Incentro Google Geocoder"
-    api_key = "Google Geocoder API Key"
-    metadata = {
-      foo : "bar"
-    }
+  name    = "Incentro Google Geocoder"
+  api_key = "Google Geocoder API Key"
+  metadata = {
+    foo : "bar"
   }
 }
\ No newline at end of file
@@ -1,7 +1,5 @@
 resource "commercelayer_inventory_model" "incentro_inventory_model" {
-  attributes {
-    name                   = "Incentro Inventory Model"
-    stock_locations_cutoff = 2
-    strategy               = "split_shipments"
-  }
+  name                   = "Incentro Inventory Model"
+  stock_locations_cutoff = 2
+  strategy               = "split_shipments"
 }
\ No newline at end of file
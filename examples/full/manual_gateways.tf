@@ -1,5 +1,3 @@
 resource "commercelayer_manual_gateway" "incentro_manual_gateway" {
-  attributes {
-    name = "Incentro Manual Gateway"
-  }
+  name = "Incentro Manual Gateway"
 }
\ No newline at end of file
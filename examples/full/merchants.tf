@@ -1,12 +1,8 @@
 resource "commercelayer_merchant" "incentro_merchant" {
-  attributes {
-    name = "Incentro Merchant"
-    metadata = {
-      foo : "bar"
-    }
+  name = "Incentro Merchant"
+  metadata = {
+    foo : "bar"
   }
 
-  relationships {
-    address_id = commercelayer_address.incentro_address.id
-  }
+  address_id = commercelayer_address.incentro_address.id
 }
\ No newline at end of file
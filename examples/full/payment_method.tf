@@ -1,11 +1,7 @@
 resource "commercelayer_payment_method" "incentro_payment_method" {
-  attributes {
-    payment_source_type = "AdyenPayment"
-    currency_code       = "EUR"
-    price_amount_cents  = 0
-  }
+  payment_source_type = "AdyenPayment"
+  currency_code       = "EUR"
+  price_amount_cents  = 0
 
-  relationships {
-    payment_gateway_id = commercelayer_adyen_gateway.incentro_adyen_gateway.id
-  }
+  payment_gateway_id = commercelayer_adyen_gateway.incentro_adyen_gateway.id
 }
\ No newline at end of file
@@ -1,7 +1,5 @@
 resource "commercelayer_paypal_gateway" "incentro_paypal_gateway" {
-  attributes {
-    name          = "Incentro Paypal Gateway"
-    client_id     = "xxxx-yyyy-zzzz"
-    client_secret = "xxxx-yyyy-zzzz"
-  }
+  name          = "Incentro Paypal Gateway"
+  client_id     = "xxxx-yyyy-zzzz"
+  client_secret = "xxxx-yyyy-zzzz"
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+resource "commercelayer_price_list" "incentro_black_friday_price_list" {
+  name          = "Incentro Black Friday Price List"
+  currency_code = "EUR"
+}
+
+resource "commercelayer_price_list_scheduler" "incentro_price_list_scheduler" {
+  name       = "Incentro Black Friday"
+  starts_at  = "2024-11-29T00:00:00Z"
+  expires_at = "2024-12-03T00:00:00Z"
+
+  market_id     = commercelayer_market.incentro_market.id
+  price_list_id = commercelayer_price_list.incentro_black_friday_price_list.id
+}
@@ -1,9 +1,7 @@
 resource "commercelayer_price_list" "incentro_price_list" {
-  attributes {
-    name          = "Incentro Price List"
-    currency_code = "EUR"
-    metadata = {
-      foo : "bar"
-    }
+  name          = "Incentro Price List"
+  currency_code = "EUR"
+  metadata = {
+    foo : "bar"
   }
 }
\ No newline at end of file
@@ -1,8 +1,6 @@
 resource "commercelayer_shipping_category" "incentro_shipping_category" {
-  attributes {
-    name = "Incentro Shipping Category"
-    metadata = {
-      foo : "bar"
-    }
+  name = "Incentro Shipping Category"
+  metadata = {
+    foo : "bar"
   }
 }
\ No newline at end of file
@@ -1,6 +1,4 @@
 resource "commercelayer_stripe_gateway" "incentro_stripe_gateway" {
-  attributes {
-    name  = "Incentro Stripe Gateway"
-    login = "xxxx-yyyy-zzzz"
-  }
+  name  = "Incentro Stripe Gateway"
+  login = "xxxx-yyyy-zzzz"
 }
\ No newline at end of file
@@ -0,0 +1,4 @@
+resource "commercelayer_subscription_model" "incentro_subscription_model" {
+  name        = "Incentro Subscription Model"
+  frequencies = ["weekly", "monthly", "0 8 * * 1"]
+}
@@ -1,6 +1,4 @@
 resource "commercelayer_taxjar_accounts" "incentro_taxjar_account" {
-  attributes {
-    name    = "Incentro Taxjar Account"
-    api_key = "TAXJAR_API_KEY"
-  }
+  name    = "Incentro Taxjar Account"
+  api_key = "TAXJAR_API_KEY"
 }
\ No newline at end of file
@@ -12,4 +12,9 @@ variable "api_endpoint" {
 
 variable "auth_endpoint" {
   type = string
-}
\ No newline at end of file
+}
+
+variable "customer_password" {
+  type      = string
+  sensitive = true
+}
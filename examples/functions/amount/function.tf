@@ -0,0 +1,6 @@
+output "free_shipping_threshold" {
+  value = provider::commercelayer::amount(
+    commercelayer_shipping_method.incentro_shipping_method.free_over_amount_cents,
+    commercelayer_shipping_method.incentro_shipping_method.currency_code,
+  )
+}
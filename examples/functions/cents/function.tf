@@ -0,0 +1,7 @@
+resource "commercelayer_shipping_method" "incentro_shipping_method" {
+  name                   = "Incentro Shipping Method"
+  scheme                 = "flat"
+  currency_code          = "EUR"
+  price_amount_cents     = provider::commercelayer::cents(4.95, "EUR")
+  free_over_amount_cents = provider::commercelayer::cents(100, "EUR")
+}
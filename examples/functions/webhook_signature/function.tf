@@ -0,0 +1,18 @@
+locals {
+  webhook_test_body = jsonencode({
+    data = {
+      type = "orders"
+      id   = "xYZkjABcde"
+    }
+  })
+}
+
+resource "terraform_data" "webhook_consumer_test" {
+  provisioner "local-exec" {
+    command = "curl --fail -X POST -H \"X-CommerceLayer-Signature: $SIGNATURE\" -d \"$BODY\" https://example.url/webhooks"
+    environment = {
+      BODY      = local.webhook_test_body
+      SIGNATURE = provider::commercelayer::webhook_signature(commercelayer_webhook.incentro_webhook.shared_secret, local.webhook_test_body)
+    }
+  }
+}
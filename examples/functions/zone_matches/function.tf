@@ -0,0 +1,18 @@
+resource "commercelayer_shipping_zone" "benelux" {
+  name               = "Benelux"
+  country_code_regex = "^(NL|BE|LU)$"
+}
+
+check "benelux_shipping_zone" {
+  assert {
+    condition     = provider::commercelayer::zone_matches(commercelayer_shipping_zone.benelux, "NL", null, "3044 BC")
+    error_message = "Rotterdam should fall within the Benelux shipping zone."
+  }
+
+  assert {
+    condition = !provider::commercelayer::zone_matches({
+      country_code_regex = "^(NL|BE|LU)$"
+    }, "DE", null, "10115")
+    error_message = "Berlin should not fall within the Benelux shipping zone."
+  }
+}
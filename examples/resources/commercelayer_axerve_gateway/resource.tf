@@ -0,0 +1,5 @@
+resource "commercelayer_axerve_gateway" "incentro_axerve_gateway" {
+  name    = "Incentro Axerve Gateway"
+  login   = "xxxx-yyyy-zzzz"
+  api_key = "aaaa-bbbb-cccc"
+}
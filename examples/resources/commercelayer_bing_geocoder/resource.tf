@@ -1,9 +1,7 @@
 resource "commercelayer_bing_geocoder" "incentro_bing_geocoder" {
-  attributes {
-    name = "Incentro Bing Geocoder"
-    key  = "Bing Virtualearth Key"
-    metadata = {
-      foo : "bar"
-    }
+  name = "Incentro Bing Geocoder"
+  key  = "Bing Virtualearth Key"
+  metadata = {
+    foo : "bar"
   }
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+resource "commercelayer_customer_group" "incentro_customer_group" {
+  name = "Incentro customer group"
+}
+
+resource "commercelayer_customer" "incentro_customer" {
+  email = "b2b-buyer@incentro.com"
+  metadata_json = jsonencode({
+    erp = {
+      debtor_number = 100042
+      payment_terms = ["invoice", "direct_debit"]
+    }
+  })
+
+  customer_group_id = commercelayer_customer_group.incentro_customer_group.id
+}
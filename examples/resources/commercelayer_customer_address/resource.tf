@@ -0,0 +1,21 @@
+resource "commercelayer_address" "incentro_address" {
+  business     = true
+  company      = "Incentro"
+  line_1       = "Van Nelleweg 1"
+  zip_code     = "3044 BC"
+  country_code = "NL"
+  city         = "Rotterdam"
+  phone        = "+31(0)10 20 20 544"
+  state_code   = "ZH"
+}
+
+resource "commercelayer_customer" "incentro_customer" {
+  email = "b2b-buyer@incentro.com"
+}
+
+resource "commercelayer_customer_address" "incentro_customer_address" {
+  customer_email = commercelayer_customer.incentro_customer.email
+
+  customer_id = commercelayer_customer.incentro_customer.id
+  address_id  = commercelayer_address.incentro_address.id
+}
@@ -1,5 +1,3 @@
 resource "commercelayer_customer_group" "incentro_customer_group" {
-  attributes {
-    name = "Incentro Customer Group"
-  }
+  name = "Incentro Customer Group"
 }
\ No newline at end of file
@@ -1,6 +1,4 @@
 resource "commercelayer_external_tax_calculator" "incentro_external_tax_calculator" {
-  attributes {
-    name               = "Incentro External Tax Calculator"
-    tax_calculator_url = "https://example.com"
-  }
+  name               = "Incentro External Tax Calculator"
+  tax_calculator_url = "https://example.com"
 }
\ No newline at end of file
@@ -1,8 +1,6 @@
 resource "commercelayer_klarna_gateway" "incentro_klarna_gateway" {
-  attributes {
-    name         = "Incentro Klarna Gateway"
-    country_code = "EU"
-    api_key      = "xxxx-yyyy-zzzz"
-    api_secret   = "xxxx-yyyy-zzzz"
-  }
+  name         = "Incentro Klarna Gateway"
+  country_code = "EU"
+  api_key      = "xxxx-yyyy-zzzz"
+  api_secret   = "xxxx-yyyy-zzzz"
 }
\ No newline at end of file
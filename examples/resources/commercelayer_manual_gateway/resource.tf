@@ -1,8 +1,6 @@
 resource "commercelayer_manual_gateway" "incentro_manual_gateway" {
-  attributes {
-    name = "Incentro Manual Gateway"
-    metadata = {
-      foo : "bar"
-    }
+  name = "Incentro Manual Gateway"
+  metadata = {
+    foo : "bar"
   }
 }
\ No newline at end of file
@@ -1,5 +1,3 @@
 resource "commercelayer_manual_tax_calculator" "incentro_manual_tax_calculator" {
-  attributes {
-    name = "Incentro Manual Tax Calculator"
-  }
+  name = "Incentro Manual Tax Calculator"
 }
\ No newline at end of file
@@ -1,21 +1,18 @@
 resource "commercelayer_adyen_gateway" "incentro_adyen_gateway" {
-  attributes {
-    name             = "Incentro Adyen Gateway"
-    merchant_account = "xxxx-yyyy-zzzz"
-    api_key          = "xxxx-yyyy-zzzz"
-    public_key       = "xxxx-yyyy-zzzz"
-    live_url_prefix  = "1797a841fbb37ca7-AdyenDemo"
-  }
+  name             = "Incentro Adyen Gateway"
+  merchant_account = "xxxx-yyyy-zzzz"
+  api_key          = "xxxx-yyyy-zzzz"
+  public_key       = "xxxx-yyyy-zzzz"
+  live_url_prefix  = "1797a841fbb37ca7-AdyenDemo"
 }
 
 resource "commercelayer_payment_method" "incentro_payment_method" {
-  attributes {
-    payment_source_type = "AdyenPayment"
-    currency_code       = "EUR"
-    price_amount_cents  = 0
-  }
+  payment_source_type = "AdyenPayment"
+  currency_code       = "EUR"
+  price_amount_cents  = 0
+  auto_capture        = true
 
-  relationships {
-    payment_gateway_id = commercelayer_adyen_gateway.incentro_adyen_gateway.id
-  }
+  auto_capture_max_amount_cents = 50000
+
+  payment_gateway_id = commercelayer_adyen_gateway.incentro_adyen_gateway.id
 }
@@ -1,6 +1,8 @@
 resource "commercelayer_price_list" "incentro_price_list" {
-  attributes {
-    name          = "Incentro Price List"
-    currency_code = "EUR"
-  }
-}
\ No newline at end of file
+  name          = "Incentro Price List"
+  currency_code = "EUR"
+  reference     = "incentro-eur"
+
+  # Take over the price list with this reference when it already exists
+  adopt_existing = true
+}
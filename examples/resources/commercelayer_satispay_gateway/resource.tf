@@ -0,0 +1,4 @@
+resource "commercelayer_satispay_gateway" "incentro_satispay_gateway" {
+  name  = "Incentro Satispay Gateway"
+  token = "xxxx-yyyy-zzzz"
+}
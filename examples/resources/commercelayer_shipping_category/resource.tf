@@ -1,5 +1,3 @@
 resource "commercelayer_shipping_category" "incentro_shipping_category" {
-  attributes {
-    name = "Incentro Shipping Category"
-  }
+  name = "Incentro Shipping Category"
 }
\ No newline at end of file
@@ -1,11 +1,23 @@
 resource "commercelayer_shipping_zone" "incentro_shipping_zone" {
-  attributes {
-    name                   = "Incentro Shipping Zone"
-    country_code_regex     = ".*"
-    not_country_code_regex = "[^i*&2@]"
-    state_code_regex       = "^dog"
-    not_state_code_regex   = "//[^\r\n]*[\r\n]"
-    zip_code_regex         = "[a-zA-Z]{2,4}"
-    not_zip_code_regex     = ".+"
+  name                   = "Incentro Shipping Zone"
+  country_code_regex     = ".*"
+  not_country_code_regex = "[^i*&2@]"
+  state_code_regex       = "^dog"
+  not_state_code_regex   = "//[^\r\n]*[\r\n]"
+  zip_code_regex         = "[a-zA-Z]{2,4}"
+  not_zip_code_regex     = ".+"
+}
+
+resource "commercelayer_shipping_zone" "benelux_shipping_zone" {
+  name               = "Benelux"
+  country_code_regex = "^(NL|BE|LU)$"
+
+  expect_match {
+    country_code = "NL"
+    zip_code     = "3044 BC"
   }
-}
\ No newline at end of file
+
+  expect_no_match {
+    country_code = "DE"
+  }
+}
@@ -1,25 +1,19 @@
 resource "commercelayer_address" "incentro_address" {
-  attributes {
-    business     = true
-    company      = "Incentro"
-    line_1       = "Van Nelleweg 1"
-    zip_code     = "3044 BC"
-    country_code = "NL"
-    city         = "Rotterdam"
-    phone        = "+31(0)10 20 20 544"
-    state_code   = "ZH"
-  }
+  business     = true
+  company      = "Incentro"
+  line_1       = "Van Nelleweg 1"
+  zip_code     = "3044 BC"
+  country_code = "NL"
+  city         = "Rotterdam"
+  phone        = "+31(0)10 20 20 544"
+  state_code   = "ZH"
 }
 
 
 resource "commercelayer_stock_location" "incentro_warehouse_location" {
-  attributes {
-    name         = "Incentro Warehouse Stock Location"
-    label_format = "PNG"
-    suppress_etd = true
-  }
+  name         = "Incentro Warehouse Stock Location"
+  label_format = "PNG"
+  suppress_etd = true
 
-  relationships {
-    address_id = commercelayer_address.incentro_address.id
-  }
+  address_id = commercelayer_address.incentro_address.id
 }
\ No newline at end of file
@@ -1,6 +1,10 @@
 resource "commercelayer_stripe_gateway" "incentro_stripe_gateway" {
-  attributes {
-    name  = "Incentro Stripe Gateway"
-    login = "xxxx-yyyy-zzzz"
-  }
-}
\ No newline at end of file
+  name              = "Incentro Stripe Gateway"
+  login             = "xxxx-yyyy-zzzz"
+  connected_account = "acct_1032D82eZvKYlo2C"
+  auto_payments     = true
+}
+
+output "stripe_webhook_endpoint_url" {
+  value = commercelayer_stripe_gateway.incentro_stripe_gateway.webhook_endpoint_url
+}
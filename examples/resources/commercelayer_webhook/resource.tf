@@ -1,11 +1,12 @@
 resource "commercelayer_webhook" "incentro_webhook" {
-  attributes {
-    name         = "Incentro Webhook"
-    topic        = "orders.create"
-    callback_url = "http://example.url"
-    include_resources = [
-      "customer",
-      "line_items"
-    ]
-  }
+  name         = "Incentro Webhook"
+  topic        = "orders.create"
+  callback_url = "http://example.url"
+  include_resources = [
+    "customer",
+    "line_items.item"
+  ]
+
+  # Re-arm the webhook whenever the circuit breaker has tripped
+  reset_circuit = true
 }
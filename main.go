@@ -1,24 +1,111 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/incentro-dc/terraform-provider-commercelayer/commercelayer"
 )
 
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		export(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "imports" {
+		imports(os.Args[2:])
+		return
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: commercelayer.Provider()}
+	providerServer, err := commercelayer.ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
 
+	var serveOpts []tf6server.ServeOpt
 	if debugMode {
-		opts.Debug = true
-		opts.ProviderAddr = "registry.terraform.io/incentro-dc/commercelayer"
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/incentro-dc/commercelayer", providerServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// export writes the resources of an existing organization as Terraform configuration, with the credentials of the
+// COMMERCELAYER_* environment variables.
+func export(args []string) {
+	var config commercelayer.ExportConfig
+	var resourceTypes string
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s export [options]\n\n", os.Args[0])
+		_, _ = fmt.Fprint(flags.Output(), "Writes the resources of the organization the COMMERCELAYER_* environment "+
+			"variables give access to as Terraform configuration, with import blocks and variables for secrets.\n\n")
+		flags.PrintDefaults()
 	}
+	flags.StringVar(&config.Dir, "dir", ".", "the directory to write the configuration to")
+	flags.StringVar(&resourceTypes, "resource-types", "",
+		"a comma separated list of the resource types to export, for example commercelayer_market (default all)")
+	_ = flags.Parse(args)
 
-	plugin.Serve(opts)
+	if resourceTypes != "" {
+		config.ResourceTypes = strings.Split(resourceTypes, ",")
+	}
+
+	err := commercelayer.Export(context.Background(), config)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// imports prints an import block for every existing resource of a type that matches the filters, with the credentials
+// of the COMMERCELAYER_* environment variables.
+func imports(args []string) {
+	config := commercelayer.ImportConfig{Filters: map[string]string{}}
+
+	flags := flag.NewFlagSet("imports", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: %s imports -resource-type <type> [-filter <predicate>=<value>]...\n\n",
+			os.Args[0])
+		_, _ = fmt.Fprint(flags.Output(), "Prints an import block for every resource of the type in the organization "+
+			"the COMMERCELAYER_* environment variables give access to that matches the filters.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&config.ResourceType, "resource-type", "",
+		"the resource type to import, for example commercelayer_stock_location")
+	flags.Func("filter", "a filter the resources must match, for example name_cont=Amsterdam (repeatable)",
+		func(value string) error {
+			predicate, filter, ok := strings.Cut(value, "=")
+			if !ok {
+				return fmt.Errorf("expected <predicate>=<value>, got %s", value)
+			}
+			config.Filters[predicate] = filter
+			return nil
+		})
+	_ = flags.Parse(args)
+
+	if config.ResourceType == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	blocks, err := commercelayer.ImportBlocks(context.Background(), config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, _ = os.Stdout.Write(blocks)
 }
@@ -1,2 +0,0 @@
-curl --location --request POST 'http://localhost:8080/__admin/recordings/stop' \
-  --header 'Accept: application/json'